@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/romanodesouza/galendario/internal/ical"
+)
+
+// Exit codes of the diff command, as in diff(1).
+const (
+	diffSame    = 0
+	diffChanged = 1
+	diffTrouble = 2
+)
+
+// runDiff implements "galendario diff old.ics new.ics", reporting the matches
+// added, removed or changed between two calendars.
+func runDiff(args []string, stdout io.Writer) int {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: galendario diff [-json] old.ics new.ics")
+		fs.PrintDefaults()
+	}
+	jsonOutput := fs.Bool("json", false, "print the differences as JSON")
+	if err := fs.Parse(args); err != nil {
+		return diffTrouble
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return diffTrouble
+	}
+
+	diffs, err := diffFiles(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		log.Print(err)
+		return diffTrouble
+	}
+
+	if *jsonOutput {
+		if diffs == nil {
+			diffs = []ical.EventDiff{}
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(diffs); err != nil {
+			log.Print(err)
+			return diffTrouble
+		}
+	} else {
+		for _, d := range diffs {
+			fmt.Fprintf(stdout, "%s %s (%s)\n", diffMarks[d.Kind], d.Summary, d.Start)
+			for _, f := range d.Fields {
+				fmt.Fprintf(stdout, "    %s: %q -> %q\n", f.Name, f.Old, f.New)
+			}
+		}
+	}
+
+	if len(diffs) > 0 {
+		return diffChanged
+	}
+	return diffSame
+}
+
+var diffMarks = map[ical.DiffKind]string{
+	ical.DiffAdded:   "+",
+	ical.DiffRemoved: "-",
+	ical.DiffChanged: "~",
+}
+
+func diffFiles(oldPath, newPath string) ([]ical.EventDiff, error) {
+	oldFile, err := os.Open(oldPath)
+	if err != nil {
+		return nil, err
+	}
+	defer oldFile.Close()
+
+	newFile, err := os.Open(newPath)
+	if err != nil {
+		return nil, err
+	}
+	defer newFile.Close()
+
+	return ical.Diff(oldFile, newFile)
+}
@@ -1,37 +1,264 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/romanodesouza/galendario/internal/clock"
 	"github.com/romanodesouza/galendario/internal/event"
 	"github.com/romanodesouza/galendario/internal/ical"
+	"github.com/romanodesouza/galendario/internal/registry"
+	"github.com/romanodesouza/galendario/internal/store"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "diff":
+			os.Exit(runDiff(os.Args[2:], os.Stdout))
+		case "publish":
+			os.Exit(runPublish(os.Args[2:], os.Stdin, os.Stdout))
+		}
+	}
+
+	sourceName := flag.String("source", event.DefaultSource,
+		fmt.Sprintf("event source (%s)", strings.Join(event.Sources(), ", ")))
+	sourceFile := flag.String("source-file", "", "calendar read by the file source, such as a published one")
+	timeout := flag.Duration("timeout", event.DefaultTimeout, "timeout for each request attempt")
+	retries := flag.Int("retries", event.DefaultMaxRetries, "retries after a network error or 5xx response")
+	cacheDir := flag.String("cache-dir", "", "directory to cache fetched agenda pages in")
+	offline := flag.Bool("offline", false, "serve agenda pages from -cache-dir only")
+	finished := flag.Bool("finished", false, "include finished matches with their final score")
+	lenient := flag.Bool("lenient", false, "skip matches that cannot be parsed and publish the rest")
+	maxErrorRatio := flag.Float64("max-error-ratio", 0.5,
+		"with -lenient, fail when the share of unparsable matches exceeds it")
+	snapshotDir := flag.String("snapshot-dir", "", "directory to save responses the agenda could not be read from")
+	selectorsPath := flag.String("selectors", "", "selector profile overriding the embedded one")
+	enrich := flag.Bool("enrich", false, "follow match links to fill in round, phase and referee")
+	enrichMaxPages := flag.Int("enrich-max-pages", event.DefaultEnrichMaxPages, "with -enrich, max match pages crawled")
+	enrichConcurrency := flag.Int("enrich-concurrency", event.DefaultEnrichConcurrency,
+		"with -enrich, max match pages fetched at once")
+	ticketSales := flag.Bool("ticket-sales", false, "add an event for each announced ticket sale opening")
+	localTime := flag.Bool("local-time", false, "add the venue's local kickoff time to away matches in another time zone")
+	club := flag.String("club", event.DefaultClub, "club whose point of view classifies matches as home, away or neutral")
+	storePath := flag.String("store", "", "file recording every match seen, for stable UIDs, SEQUENCE and the like")
+	uidsPath := flag.String("uids", "", "file remembering match UIDs, so that rescheduled matches keep them")
+	tournamentsPath := flag.String("tournaments", "", "tournament registry extending the embedded one")
+	teamsPath := flag.String("teams", "", "team registry extending the embedded one")
+	stadiumsPath := flag.String("stadiums", "", "stadium registry extending the embedded one")
+	nowFlag := flag.String("now", "", "run as of the given date (YYYY-MM-DD) or time (RFC 3339)")
+	flag.Parse()
+
+	if *offline && *cacheDir == "" {
+		log.Fatal("-offline requires -cache-dir")
+	}
+	if *offline && *enrich {
+		log.Fatal("-enrich crawls match pages, which -offline forbids")
+	}
+	if *storePath != "" && *uidsPath != "" {
+		log.Fatal("-store keeps match UIDs itself, drop -uids")
+	}
+
 	// Load location
 	loc, err := time.LoadLocation("America/Sao_Paulo")
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	// Set up clock
+	clk := clock.System
+	if *nowFlag != "" {
+		now, err := parseNow(*nowFlag, loc)
+		if err != nil {
+			log.Fatal(err)
+		}
+		clk = clock.Fixed(now)
+	}
+
+	// Load selector profile
+	sel := event.DefaultSelectors()
+	if *selectorsPath != "" {
+		sel, err = event.LoadSelectors(*selectorsPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Load registries
+	tournaments := registry.DefaultTournaments()
+	if *tournamentsPath != "" {
+		tournaments, err = registry.LoadTournaments(*tournamentsPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	teams := registry.DefaultTeams()
+	if *teamsPath != "" {
+		teams, err = registry.LoadTeams(*teamsPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	stadiums := registry.DefaultStadiums()
+	if *stadiumsPath != "" {
+		stadiums, err = registry.LoadStadiums(*stadiumsPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	// Fetch events
-	startDate := time.Now().In(loc)
+	startDate := clk.Now().In(loc)
 	endDate := endOfMonth(startDate.AddDate(0, 3, 0))
-	events, err := event.FetchAll(startDate, endDate)
+	src, err := event.Lookup(*sourceName)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if f, ok := src.(ical.FileSource); ok {
+		if *sourceFile == "" {
+			log.Fatalf("-source %s requires -source-file", *sourceName)
+		}
+		f.Path = *sourceFile
+		src = f
+	}
+	if f, ok := src.(*event.Fetcher); ok {
+		fetcher := *f
+		fetcher.Timeout = *timeout
+		fetcher.MaxRetries = *retries
+		fetcher.Offline = *offline
+		fetcher.Clock = clk
+		fetcher.SnapshotDir = *snapshotDir
+		if *finished {
+			fetcher.ExtractOptions = append(fetcher.ExtractOptions, event.WithFinished())
+		}
+		if *lenient {
+			fetcher.ExtractOptions = append(fetcher.ExtractOptions, event.WithLenient())
+		}
+		fetcher.ExtractOptions = append(fetcher.ExtractOptions,
+			event.WithSelectors(sel), event.WithTournaments(tournaments), event.WithTeams(teams),
+			event.WithStadiums(stadiums))
+		if *cacheDir != "" {
+			fetcher.Cache = event.NewCache(*cacheDir)
+		}
+		src = &fetcher
+	}
+	events, err := src.Fetch(context.Background(), startDate, endDate)
+	var parseErrs event.ParseErrors
+	switch {
+	case errors.As(err, &parseErrs):
+		for _, perr := range parseErrs {
+			log.Printf("warning: skipping match: %v (xpath: %s)", perr, perr.XPath)
+		}
+		ratio := float64(len(parseErrs)) / float64(len(events)+len(parseErrs))
+		if ratio > *maxErrorRatio {
+			log.Fatalf("%d of %d matches could not be parsed", len(parseErrs), len(events)+len(parseErrs))
+		}
+	case err != nil:
+		log.Fatal(err)
+	}
+	if unknown := teams.Unknown(); len(unknown) > 0 {
+		log.Printf("warning: unknown teams: %s", strings.Join(unknown, ", "))
+	}
+
+	// Enrich events
+	if *enrich {
+		enricher := event.NewEnricher(nil)
+		enricher.Timeout = *timeout
+		enricher.MaxPages = *enrichMaxPages
+		enricher.Concurrency = *enrichConcurrency
+		enricher.Selectors = sel
+		events, err = enricher.Enrich(context.Background(), events)
+		if err != nil {
+			log.Printf("warning: could not enrich every match: %v", err)
+		}
+	}
+
+	// Record history
+	var st *store.Store
+	if *storePath != "" {
+		st, err = store.Open(*storePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// Matches skipped in lenient mode are not removed ones
+		var changes []store.Change
+		if len(parseErrs) > 0 {
+			changes = st.RecordPartial(events, startDate, clk.Now())
+		} else {
+			changes = st.Record(events, startDate, endDate, clk.Now())
+		}
+		for _, c := range changes {
+			ev := c.After
+			if ev == nil {
+				ev = c.Before
+			}
+			log.Printf("%s: %s x %s on %s", c.Kind, ev.HomeTeam, ev.AwayTeam, ev.DateTime.Format(time.DateOnly))
+		}
+	}
 
 	// Build calendar
-	cal := ical.NewCalendar("Galendário")
+	calOpts := []ical.Option{
+		ical.WithTournaments(tournaments),
+		ical.WithStadiums(stadiums),
+		ical.WithClub(teams.Normalize(*club)),
+		ical.WithClock(clk),
+	}
+	if st != nil {
+		calOpts = append(calOpts, ical.WithHistory(st))
+	}
+	if *ticketSales {
+		calOpts = append(calOpts, ical.WithTicketSales())
+	}
+	if *localTime {
+		calOpts = append(calOpts, ical.WithLocalTime())
+	}
+	var uids *ical.UIDs
+	if *uidsPath != "" {
+		uids, err = ical.LoadUIDs(*uidsPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		uids.StartDate = startDate
+		calOpts = append(calOpts, ical.WithUIDs(uids))
+	}
+	cal := ical.NewCalendar("Galendário", calOpts...)
 	cal.AddEvents(events)
 
 	// Print calendar
 	if err := cal.SerializeTo(os.Stdout); err != nil {
 		log.Fatal(err)
 	}
+
+	// Save state
+	if st != nil {
+		if err := st.Save(); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if uids != nil {
+		if err := uids.Save(*uidsPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func parseNow(input string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, input, loc); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, input)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid -now value %q: expected YYYY-MM-DD or RFC 3339", input)
+	}
+	return t.In(loc), nil
 }
 
 func startOfMonth(date time.Time) time.Time {
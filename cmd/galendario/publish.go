@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/romanodesouza/galendario/internal/gist"
+)
+
+// Exit codes of the publish command, following the diff ones.
+const (
+	publishSkipped = 0
+	publishUpdated = 1
+	publishTrouble = 2
+)
+
+// runPublish implements "galendario publish gist [calendar.ics]", updating
+// the calendar published to a gist when its events changed.
+func runPublish(args []string, stdin io.Reader, stdout io.Writer) int {
+	if len(args) == 0 || args[0] != "gist" {
+		fmt.Fprintln(os.Stderr, "usage: galendario publish gist [flags] [calendar.ics]")
+		return publishTrouble
+	}
+
+	fs := flag.NewFlagSet("publish gist", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: galendario publish gist [flags] [calendar.ics]")
+		fmt.Fprintln(fs.Output(), "Reads the calendar from stdin when no file is given.")
+		fs.PrintDefaults()
+	}
+	id := fs.String("gist", os.Getenv("GH_GIST"), "gist ID (default $GH_GIST)")
+	filename := fs.String("file", gist.DefaultFilename, "name of the calendar file in the gist")
+	apiURL := fs.String("api-url", gist.DefaultBaseURL, "base URL of the GitHub API")
+	tokenEnv := fs.String("token-env", "GH_BEARER", "environment variable holding the API token")
+	timeout := fs.Duration("timeout", gist.DefaultTimeout, "timeout for each API request")
+	if err := fs.Parse(args[1:]); err != nil {
+		return publishTrouble
+	}
+	if *id == "" || fs.NArg() > 1 {
+		fs.Usage()
+		return publishTrouble
+	}
+	token := os.Getenv(*tokenEnv)
+	if token == "" {
+		log.Printf("missing API token: $%s is not set", *tokenEnv)
+		return publishTrouble
+	}
+
+	in := stdin
+	if fs.NArg() == 1 {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			log.Print(err)
+			return publishTrouble
+		}
+		defer f.Close()
+		in = f
+	}
+	calendar, err := io.ReadAll(in)
+	if err != nil {
+		log.Print(err)
+		return publishTrouble
+	}
+
+	client := gist.NewClient(token)
+	client.BaseURL = *apiURL
+	client.Timeout = *timeout
+	updated, err := client.Publish(context.Background(), *id, *filename, calendar)
+	if err != nil {
+		log.Print(err)
+		return publishTrouble
+	}
+
+	if !updated {
+		fmt.Fprintln(stdout, "No updates")
+		return publishSkipped
+	}
+	fmt.Fprintln(stdout, "Calendar has been updated")
+	return publishUpdated
+}
@@ -6,13 +6,13 @@ toolchain go1.23.5
 
 require (
 	github.com/antchfx/htmlquery v1.3.1
+	github.com/antchfx/xpath v1.3.0
 	github.com/arran4/golang-ical v0.2.8
 	github.com/google/go-cmp v0.6.0
 	golang.org/x/net v0.40.0
+	golang.org/x/text v0.25.0
 )
 
 require (
-	github.com/antchfx/xpath v1.3.0 // indirect
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
-	golang.org/x/text v0.25.0 // indirect
 )
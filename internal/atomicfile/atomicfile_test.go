@@ -0,0 +1,46 @@
+package atomicfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/romanodesouza/galendario/internal/atomicfile"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.json")
+
+	for _, content := range []string{"first", "second"} {
+		if err := atomicfile.Write(path, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != content {
+			t.Errorf("unexpected content, want %q, got %q", content, got)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mode := info.Mode().Perm(); mode != 0o644 {
+			t.Errorf("unexpected mode, want %v, got %v", os.FileMode(0o644), mode)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected no temp file left behind, got %v", entries)
+	}
+
+	if err := atomicfile.Write(filepath.Join(dir, "missing", "file.json"), nil); err == nil {
+		t.Error("expected an error writing to a missing dir")
+	}
+}
@@ -0,0 +1,35 @@
+package clock
+
+import (
+	"time"
+)
+
+// Clock tells the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// Func adapts a plain function to the Clock interface.
+type Func func() time.Time
+
+func (f Func) Now() time.Time {
+	return f()
+}
+
+// System is the wall clock.
+var System Clock = Func(time.Now)
+
+// Fixed returns a clock that is always at t.
+func Fixed(t time.Time) Clock {
+	return Func(func() time.Time {
+		return t
+	})
+}
+
+// OrSystem returns c, or System when c is nil.
+func OrSystem(c Clock) Clock {
+	if c == nil {
+		return System
+	}
+	return c
+}
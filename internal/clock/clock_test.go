@@ -0,0 +1,32 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/romanodesouza/galendario/internal/clock"
+)
+
+func TestFixed(t *testing.T) {
+	want := time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC)
+	c := clock.Fixed(want)
+
+	for i := 0; i < 2; i++ {
+		if got := c.Now(); !got.Equal(want) {
+			t.Fatalf("unexpected time, want %v, got %v", want, got)
+		}
+	}
+}
+
+func TestOrSystem(t *testing.T) {
+	fixed := clock.Fixed(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if got := clock.OrSystem(fixed); !got.Now().Equal(fixed.Now()) {
+		t.Errorf("expected the given clock to be kept")
+	}
+
+	before := time.Now()
+	got := clock.OrSystem(nil).Now()
+	if got.Before(before) {
+		t.Errorf("expected the system clock, got %v", got)
+	}
+}
@@ -0,0 +1,82 @@
+package event
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/romanodesouza/galendario/internal/atomicfile"
+)
+
+var (
+	ErrCacheMiss = errors.New("page not found in cache")
+)
+
+// CachedPage is a raw agenda page as stored on disk.
+type CachedPage struct {
+	URL          string      `json:"url"`
+	Form         string      `json:"form"`
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         []byte      `json:"body"`
+	FetchedAt    time.Time   `json:"fetched_at"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+}
+
+// Cache stores raw agenda pages in a directory, one file per set of form
+// parameters.
+type Cache struct {
+	Dir string
+}
+
+func NewCache(dir string) *Cache {
+	return &Cache{Dir: dir}
+}
+
+// Get returns the page cached for form, or ErrCacheMiss.
+func (c *Cache) Get(form url.Values) (*CachedPage, error) {
+	b, err := os.ReadFile(c.path(form))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, fmt.Errorf("Cache.Get(): %s: %w", form.Encode(), ErrCacheMiss)
+	case err != nil:
+		return nil, fmt.Errorf("Cache.Get(): could not read cache file: %w", err)
+	}
+
+	var page CachedPage
+	if err := json.Unmarshal(b, &page); err != nil {
+		return nil, fmt.Errorf("Cache.Get(): could not decode cache file: %w", err)
+	}
+	return &page, nil
+}
+
+// Put stores page under form, replacing any previous entry.
+func (c *Cache) Put(form url.Values, page *CachedPage) error {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return fmt.Errorf("Cache.Put(): could not create cache dir: %w", err)
+	}
+
+	page.Form = form.Encode()
+	b, err := json.Marshal(page)
+	if err != nil {
+		return fmt.Errorf("Cache.Put(): could not encode page: %w", err)
+	}
+
+	// Write atomically so a crash never leaves a truncated entry.
+	if err := atomicfile.Write(c.path(form), b); err != nil {
+		return fmt.Errorf("Cache.Put(): %w", err)
+	}
+	return nil
+}
+
+func (c *Cache) path(form url.Values) string {
+	return filepath.Join(c.Dir, fmt.Sprintf("%x.json", sha256.Sum256([]byte(form.Encode()))))
+}
@@ -0,0 +1,109 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/romanodesouza/galendario/internal/event"
+)
+
+func TestCache(t *testing.T) {
+	cache := event.NewCache(t.TempDir())
+	form := url.Values{"data-inicio": []string{"01/01/2024"}, "data-final": []string{"31/03/2024"}}
+
+	if _, err := cache.Get(form); !errors.Is(err, event.ErrCacheMiss) {
+		t.Fatalf("err: expected %v, got %v", event.ErrCacheMiss, err)
+	}
+
+	want := &event.CachedPage{
+		Body:      []byte("<html></html>"),
+		FetchedAt: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		ETag:      `"abc"`,
+	}
+	if err := cache.Put(form, want); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := cache.Get(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Cache.Get() mismatch (-want +got):\n%s", diff)
+	}
+
+	other := url.Values{"data-inicio": []string{"01/02/2024"}, "data-final": []string{"31/03/2024"}}
+	if _, err := cache.Get(other); !errors.Is(err, event.ErrCacheMiss) {
+		t.Errorf("err: expected %v, got %v", event.ErrCacheMiss, err)
+	}
+}
+
+func TestFetcherCache(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := os.ReadFile(".testdata/agenda.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var conditional int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write(page)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 6, 0)
+
+	f := event.NewFetcher(srv.Client())
+	f.URL = srv.URL
+	f.Cache = event.NewCache(dir)
+
+	for i := 0; i < 2; i++ {
+		events, err := f.Fetch(context.Background(), start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 3 {
+			t.Fatalf("unexpected length of events, want 3, got %d", len(events))
+		}
+	}
+	if conditional != 1 {
+		t.Errorf("unexpected conditional requests, want 1, got %d", conditional)
+	}
+
+	srv.Close()
+	offline := event.NewFetcher(nil)
+	offline.URL = srv.URL
+	offline.Cache = event.NewCache(dir)
+	offline.Offline = true
+
+	events, err := offline.Fetch(context.Background(), start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 {
+		t.Errorf("unexpected length of events, want 3, got %d", len(events))
+	}
+
+	if _, err := offline.Fetch(context.Background(), start, end.AddDate(0, 1, 0)); !errors.Is(err, event.ErrCacheMiss) {
+		t.Errorf("err: expected %v, got %v", event.ErrCacheMiss, err)
+	}
+}
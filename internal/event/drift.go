@@ -0,0 +1,173 @@
+package event
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/antchfx/htmlquery"
+)
+
+// Fingerprint summarizes the structure of the agenda page: the class names
+// found under the selectors' Container.
+type Fingerprint struct {
+	Classes []string `json:"classes"`
+	Hash    string   `json:"hash"`
+}
+
+// FingerprintPage computes the fingerprint of an agenda page body.
+func FingerprintPage(body []byte, sel *Selectors) (Fingerprint, error) {
+	doc, err := htmlquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		return Fingerprint{}, fmt.Errorf("FingerprintPage(): could not parse input: %w", err)
+	}
+
+	containers, err := htmlquery.QueryAll(doc, sel.Container)
+	if err != nil {
+		return Fingerprint{}, fmt.Errorf("FingerprintPage(): could not query container: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, container := range containers {
+		nodes, err := htmlquery.QueryAll(container, "//*[@class]")
+		if err != nil {
+			return Fingerprint{}, fmt.Errorf("FingerprintPage(): could not query class nodes: %w", err)
+		}
+		for _, node := range nodes {
+			for _, class := range strings.Fields(htmlquery.SelectAttr(node, "class")) {
+				seen[class] = true
+			}
+		}
+	}
+
+	var fp Fingerprint
+	for class := range seen {
+		fp.Classes = append(fp.Classes, class)
+	}
+	slices.Sort(fp.Classes)
+	fp.Hash = fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(fp.Classes, " "))))
+	return fp, nil
+}
+
+// Missing returns the expected classes absent from the fingerprint.
+func (fp Fingerprint) Missing(expected []string) []string {
+	var missing []string
+	for _, class := range expected {
+		if _, found := slices.BinarySearch(fp.Classes, class); !found {
+			missing = append(missing, class)
+		}
+	}
+	return missing
+}
+
+const (
+	DriftOutage       = "outage"
+	DriftLayoutChange = "layout change"
+	DriftParseFailure = "parse failure"
+)
+
+// DriftReport describes a response the agenda could not be read from.
+type DriftReport struct {
+	Time         time.Time   `json:"time"`
+	URL          string      `json:"url"`
+	Form         string      `json:"form"`
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header,omitempty"`
+	CalendarPage bool        `json:"calendar_page"`
+	Fingerprint  Fingerprint `json:"fingerprint"`
+	// Missing lists the expected classes that disappeared from the page.
+	Missing []string `json:"missing,omitempty"`
+	Error   string   `json:"error"`
+	// Snapshot is the directory the response was saved to.
+	Snapshot string `json:"-"`
+}
+
+// Kind tells an outage, where the site did not serve the agenda, from a
+// layout change, where it did but its structure moved.
+func (r *DriftReport) Kind() string {
+	switch {
+	case r.StatusCode != http.StatusOK && r.StatusCode != http.StatusNotModified:
+		return DriftOutage
+	case !r.CalendarPage || len(r.Missing) > 0:
+		return DriftLayoutChange
+	}
+	return DriftParseFailure
+}
+
+// DriftError wraps a fetch or extraction error with its drift report.
+type DriftError struct {
+	Report *DriftReport
+	Err    error
+}
+
+func (e *DriftError) Error() string {
+	msg := fmt.Sprintf("%v (%s", e.Err, e.Report.Kind())
+	if len(e.Report.Missing) > 0 {
+		msg += fmt.Sprintf(", missing classes: %s", strings.Join(e.Report.Missing, ", "))
+	}
+	if e.Report.Snapshot != "" {
+		msg += fmt.Sprintf(", snapshot: %s", e.Report.Snapshot)
+	}
+	return msg + ")"
+}
+
+func (e *DriftError) Unwrap() error {
+	return e.Err
+}
+
+// newDriftReport analyses a response the agenda could not be read from.
+func newDriftReport(page *CachedPage, sel *Selectors, err error) *DriftReport {
+	report := &DriftReport{
+		Time:       page.FetchedAt,
+		URL:        page.URL,
+		Form:       page.Form,
+		StatusCode: page.StatusCode,
+		Header:     page.Header,
+		Error:      err.Error(),
+	}
+
+	// Error pages say nothing about the agenda layout
+	if report.Kind() == DriftOutage {
+		return report
+	}
+
+	if doc, perr := htmlquery.Parse(bytes.NewReader(page.Body)); perr == nil {
+		report.CalendarPage = isCalendarPage(doc, sel)
+	}
+	if fp, ferr := FingerprintPage(page.Body, sel); ferr == nil {
+		report.Fingerprint = fp
+		report.Missing = fp.Missing(sel.ExpectedClasses)
+	}
+	return report
+}
+
+// saveSnapshot writes the response body and report into a new timestamped
+// directory under dir.
+func saveSnapshot(dir string, page *CachedPage, report *DriftReport) error {
+	snapshot := filepath.Join(dir, page.FetchedAt.UTC().Format("20060102T150405.000Z"))
+	if err := os.MkdirAll(snapshot, 0o755); err != nil {
+		return fmt.Errorf("could not create snapshot dir: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(snapshot, "body.html"), page.Body, 0o644); err != nil {
+		return fmt.Errorf("could not write snapshot body: %w", err)
+	}
+
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode snapshot report: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(snapshot, "report.json"), b, 0o644); err != nil {
+		return fmt.Errorf("could not write snapshot report: %w", err)
+	}
+
+	report.Snapshot = snapshot
+	return nil
+}
@@ -0,0 +1,199 @@
+package event_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/romanodesouza/galendario/internal/clock"
+	"github.com/romanodesouza/galendario/internal/event"
+)
+
+func TestFetcherDrift(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	agenda, err := os.ReadFile(".testdata/agenda.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	notFound, err := os.ReadFile(".testdata/404.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		status      int
+		body        []byte
+		wantKind    string
+		wantMissing []string
+	}{
+		{
+			name:     "it should report an outage on 5xx responses",
+			status:   http.StatusServiceUnavailable,
+			body:     []byte("upstream unavailable"),
+			wantKind: event.DriftOutage,
+		},
+		{
+			name:        "it should report a layout change when the page is not the agenda",
+			status:      http.StatusOK,
+			body:        notFound,
+			wantKind:    event.DriftLayoutChange,
+			wantMissing: []string{"partida", "partida-data", "partida-campeonato", "partida-placar"},
+		},
+		{
+			name:        "it should report a layout change when expected classes disappear",
+			status:      http.StatusOK,
+			body:        bytes.ReplaceAll(agenda, []byte("partida-campeonato"), []byte("partida-torneio")),
+			wantKind:    event.DriftLayoutChange,
+			wantMissing: []string{"partida-campeonato"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Test", "drift")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write(tt.body)
+			}))
+			defer srv.Close()
+
+			dir := t.TempDir()
+			f := event.NewFetcher(srv.Client())
+			f.URL = srv.URL
+			f.MaxRetries = 0
+			f.SnapshotDir = dir
+			f.Clock = clock.Fixed(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+			start := time.Date(2024, 1, 1, 0, 0, 0, 0, loc)
+			_, err := f.Fetch(context.Background(), start, start.AddDate(0, 6, 0))
+
+			var derr *event.DriftError
+			if !errors.As(err, &derr) {
+				t.Fatalf("err: expected *event.DriftError, got %v", err)
+			}
+			if got := derr.Report.Kind(); got != tt.wantKind {
+				t.Errorf("unexpected drift kind, want %q, got %q", tt.wantKind, got)
+			}
+			if diff := cmp.Diff(tt.wantMissing, derr.Report.Missing); diff != "" {
+				t.Errorf("missing classes mismatch (-want +got):\n%s", diff)
+			}
+
+			snapshot := filepath.Join(dir, "20240101T120000.000Z")
+			if derr.Report.Snapshot != snapshot {
+				t.Errorf("unexpected snapshot dir, want %s, got %s", snapshot, derr.Report.Snapshot)
+			}
+			body, err := os.ReadFile(filepath.Join(snapshot, "body.html"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(body, tt.body) {
+				t.Errorf("unexpected snapshot body")
+			}
+			if _, err := os.Stat(filepath.Join(snapshot, "report.json")); err != nil {
+				t.Error(err)
+			}
+			if got := derr.Report.Header.Get("X-Test"); got != "drift" {
+				t.Errorf("unexpected snapshot header, got %q", got)
+			}
+		})
+	}
+}
+
+func TestFetcherLenientDrift(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	agenda, err := os.ReadFile(".testdata/agenda_malformed.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		body      []byte
+		wantDrift bool
+	}{
+		{
+			name: "it should not report a drift when only some matches are malformed",
+			body: agenda,
+		},
+		{
+			name:      "it should report a drift when no match could be parsed",
+			body:      bytes.ReplaceAll(agenda, []byte("19/01 às 16:00"), []byte("19/01 às tarde")),
+			wantDrift: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write(tt.body)
+			}))
+			defer srv.Close()
+
+			dir := t.TempDir()
+			f := event.NewFetcher(srv.Client())
+			f.URL = srv.URL
+			f.MaxRetries = 0
+			f.SnapshotDir = dir
+			f.ExtractOptions = []event.ExtractOption{event.WithLenient()}
+
+			start := time.Date(2024, 1, 1, 0, 0, 0, 0, loc)
+			_, err := f.Fetch(context.Background(), start, start.AddDate(0, 6, 0))
+
+			var errs event.ParseErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("err: expected event.ParseErrors, got %v", err)
+			}
+			var derr *event.DriftError
+			if got := errors.As(err, &derr); got != tt.wantDrift {
+				t.Errorf("err: expected drift %v, got %v", tt.wantDrift, err)
+			}
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(entries) > 0; got != tt.wantDrift {
+				t.Errorf("unexpected snapshots: %v", entries)
+			}
+		})
+	}
+}
+
+func TestFingerprintPage(t *testing.T) {
+	agenda, err := os.ReadFile(".testdata/agenda.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sel := event.DefaultSelectors()
+	fp, err := event.FingerprintPage(agenda, sel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing := fp.Missing(sel.ExpectedClasses); missing != nil {
+		t.Errorf("unexpected missing classes: %v", missing)
+	}
+
+	other, err := event.FingerprintPage(bytes.ReplaceAll(agenda, []byte("partida-placar"), []byte("placar")), sel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fp.Hash == other.Hash {
+		t.Errorf("expected fingerprints of different layouts to differ")
+	}
+}
@@ -0,0 +1,188 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html"
+)
+
+const (
+	DefaultEnrichConcurrency = 4
+	DefaultEnrichMaxPages    = 20
+)
+
+// Enricher follows the match links found on the agenda and fills in the
+// details only the match pages carry.
+type Enricher struct {
+	// Client performs the HTTP requests. http.DefaultClient is used when nil.
+	Client *http.Client
+	// BaseURL resolves relative match links.
+	BaseURL string
+	// Timeout bounds each page request.
+	Timeout time.Duration
+	// Concurrency bounds the pages fetched at once.
+	Concurrency int
+	// MaxPages caps the pages crawled per run. Events past the cap are left
+	// as they are.
+	MaxPages int
+	// Selectors holds the detail page expressions. The default profile is
+	// used when nil.
+	Selectors *Selectors
+}
+
+// NewEnricher returns an Enricher using client and the default settings.
+func NewEnricher(client *http.Client) *Enricher {
+	return &Enricher{
+		Client:      client,
+		BaseURL:     baseURL,
+		Timeout:     DefaultTimeout,
+		Concurrency: DefaultEnrichConcurrency,
+		MaxPages:    DefaultEnrichMaxPages,
+	}
+}
+
+// Enrich returns a copy of events with the match page details filled in.
+// Pages that fail are skipped; their errors are joined in the returned error,
+// which comes along with the enriched events.
+func (e *Enricher) Enrich(ctx context.Context, events []Event) ([]Event, error) {
+	enriched := make([]Event, len(events))
+	copy(enriched, events)
+
+	sel := e.Selectors
+	if sel == nil {
+		sel = DefaultSelectors()
+	}
+	concurrency := max(e.Concurrency, 1)
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		errs  []error
+		sem   = make(chan struct{}, concurrency)
+		pages = 0
+	)
+loop:
+	for i := range enriched {
+		if enriched[i].MatchURL == "" {
+			continue
+		}
+		if e.MaxPages > 0 && pages >= e.MaxPages {
+			break
+		}
+		pages++
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			errs = append(errs, ctx.Err())
+			mu.Unlock()
+			break loop
+		}
+		wg.Add(1)
+		go func(ev *Event) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := e.enrichOne(ctx, ev, sel); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(&enriched[i])
+	}
+	wg.Wait()
+
+	return enriched, errors.Join(errs...)
+}
+
+func (e *Enricher) enrichOne(ctx context.Context, ev *Event, sel *Selectors) error {
+	link, err := e.resolve(ev.MatchURL)
+	if err != nil {
+		return fmt.Errorf("Enrich(): invalid match link %q: %w", ev.MatchURL, err)
+	}
+
+	doc, err := e.fetch(ctx, link)
+	if err != nil {
+		return err
+	}
+
+	ev.MatchURL = link
+	ev.Round = detailValue(doc, sel.DetailRound)
+	ev.Phase = detailValue(doc, sel.DetailPhase)
+	ev.Referee = detailValue(doc, sel.DetailReferee)
+	return nil
+}
+
+func (e *Enricher) resolve(link string) (string, error) {
+	ref, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+	if ref.IsAbs() || e.BaseURL == "" {
+		return ref.String(), nil
+	}
+	base, err := url.Parse(e.BaseURL)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+func (e *Enricher) fetch(ctx context.Context, link string) (*html.Node, error) {
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not build GET request object for %s: %w", link, err)
+	}
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/115.0")
+
+	client := e.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not make GET request to %s: %w", link, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected status code from %s: %d", link, resp.StatusCode)
+	}
+
+	doc, err := htmlquery.Parse(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse match page %s: %w", link, err)
+	}
+	return doc, nil
+}
+
+// detailValue returns the text after the first colon of the node matched by
+// expr, or its whole text when there is none.
+func detailValue(doc *html.Node, expr string) string {
+	node, err := htmlquery.Query(doc, expr)
+	if err != nil || node == nil {
+		return ""
+	}
+	text := htmlquery.InnerText(node)
+	if _, value, found := strings.Cut(text, ":"); found {
+		text = value
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
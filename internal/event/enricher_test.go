@@ -0,0 +1,91 @@
+package event_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/romanodesouza/galendario/internal/event"
+)
+
+func TestEnricher(t *testing.T) {
+	var (
+		requests atomic.Int32
+		inFlight atomic.Int32
+		peak     atomic.Int32
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		if r.URL.Path == "/partida/missing/" {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeFile(w, r, ".testdata/partida.html")
+	}))
+	defer srv.Close()
+
+	events := []event.Event{
+		{HomeTeam: "Atlético", AwayTeam: "Peñarol", MatchURL: srv.URL + "/partida/atletico-x-penarol/"},
+		{HomeTeam: "Atlético", AwayTeam: "Caracas"},
+		{HomeTeam: "Caracas", AwayTeam: "Atlético", MatchURL: "/partida/missing/"},
+		{HomeTeam: "Peñarol", AwayTeam: "Atlético", MatchURL: "/partida/penarol-x-atletico/"},
+		{HomeTeam: "Atlético", AwayTeam: "Rosario Central", MatchURL: "/partida/atletico-x-rosario-central/"},
+	}
+
+	e := event.NewEnricher(srv.Client())
+	e.BaseURL = srv.URL + "/futebol/agenda"
+	e.Concurrency = 2
+	e.MaxPages = 3
+
+	got, err := e.Enrich(context.Background(), events)
+	if err == nil {
+		t.Errorf("err: expected the missing page to be reported")
+	}
+
+	want := []event.Event{
+		{
+			HomeTeam: "Atlético",
+			AwayTeam: "Peñarol",
+			MatchURL: srv.URL + "/partida/atletico-x-penarol/",
+			Round:    "4ª rodada",
+			Phase:    "Fase de grupos",
+			Referee:  "Wilmar Roldán (COL)",
+		},
+		{HomeTeam: "Atlético", AwayTeam: "Caracas"},
+		{HomeTeam: "Caracas", AwayTeam: "Atlético", MatchURL: "/partida/missing/"},
+		{
+			HomeTeam: "Peñarol",
+			AwayTeam: "Atlético",
+			MatchURL: srv.URL + "/partida/penarol-x-atletico/",
+			Round:    "4ª rodada",
+			Phase:    "Fase de grupos",
+			Referee:  "Wilmar Roldán (COL)",
+		},
+		{HomeTeam: "Atlético", AwayTeam: "Rosario Central", MatchURL: "/partida/atletico-x-rosario-central/"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Enrich() mismatch (-want +got):\n%s", diff)
+	}
+
+	if n := requests.Load(); n != 3 {
+		t.Errorf("unexpected crawled pages, want 3, got %d", n)
+	}
+	if n := peak.Load(); n > 2 {
+		t.Errorf("unexpected concurrency, want at most 2, got %d", n)
+	}
+	if events[0].Round != "" {
+		t.Errorf("expected input events to be left untouched")
+	}
+}
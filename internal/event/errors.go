@@ -0,0 +1,58 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError describes a match of the agenda page that could not be parsed.
+type ParseError struct {
+	// Index of the match node within the page, in page order.
+	Index int
+	// Field that failed, such as "datetime" or "teams".
+	Field string
+	// Raw text of the field, when it could be read.
+	Raw string
+	// XPath that failed, relative to the match node.
+	XPath string
+	Err   error
+}
+
+func newParseError(field, raw, xpath string, err error) *ParseError {
+	return &ParseError{
+		Field: field,
+		Raw:   strings.TrimSpace(raw),
+		XPath: xpath,
+		Err:   err,
+	}
+}
+
+func (e *ParseError) Error() string {
+	if e.Raw != "" {
+		return fmt.Sprintf("ExtractEvents(): match #%d: %s %q: %v", e.Index, e.Field, e.Raw, e.Err)
+	}
+	return fmt.Sprintf("ExtractEvents(): match #%d: %s: %v", e.Index, e.Field, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors is returned along with partial results in lenient mode.
+type ParseErrors []*ParseError
+
+func (errs ParseErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (errs ParseErrors) Unwrap() []error {
+	unwrapped := make([]error, len(errs))
+	for i, err := range errs {
+		unwrapped[i] = err
+	}
+	return unwrapped
+}
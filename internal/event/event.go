@@ -1,15 +1,19 @@
 package event
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
-	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/antchfx/htmlquery"
+	"github.com/romanodesouza/galendario/internal/clock"
+	"github.com/romanodesouza/galendario/internal/registry"
 	"golang.org/x/net/html"
 )
 
@@ -19,161 +23,520 @@ const (
 
 var (
 	ErrUnexpectedInput = errors.New("unexpected html input")
+	ErrDateOutOfRange  = errors.New("date outside of requested range")
 )
 
 type Event struct {
 	Tournament string
 	Stadium    string
 	DateTime   time.Time
-	HomeTeam   string
-	AwayTeam   string
+	TimeStatus TimeStatus
+	Status     Status
+	// LocalDateTime is DateTime in the venue's time zone, for a confirmed
+	// kickoff at a stadium the registry knows; zero otherwise.
+	LocalDateTime time.Time
+	HomeTeam      string
+	AwayTeam      string
+	// Final score of finished matches, nil otherwise.
+	HomeScore *int
+	AwayScore *int
+	// Penalty shootout score, nil when there was none.
+	HomePenalties *int
+	AwayPenalties *int
+	// Broadcasts lists the TV channels and streaming services showing the match.
+	Broadcasts []string
+	// MatchURL links to the match detail page, when the agenda has one.
+	MatchURL string
+	// TicketSale is set when the agenda announces ticket sales.
+	TicketSale *TicketSale
+	// Details filled in by an Enricher from the match page.
+	Round   string
+	Phase   string
+	Referee string
 }
 
-func FetchAll(startDate, endDate time.Time) ([]Event, error) {
-	body := url.Values{
-		"data-inicio": []string{startDate.Format("02/01/2006")},
-		"data-final":  []string{endDate.Format("02/01/2006")},
+// MatchKey identifies a match regardless of its date, so that it can be
+// followed when rescheduled: the tournament and the teams. The round is left
+// out, as only enriched events carry it; a pairing that recurs within a
+// tournament is told apart by date.
+func (e Event) MatchKey() string {
+	return fmt.Sprintf("%s:%s:%s", e.Tournament, e.HomeTeam, e.AwayTeam)
+}
+
+// TicketSale describes where and when tickets for a match go on sale.
+type TicketSale struct {
+	URL string
+	// Start is when sales open, zero when not announced. Only its date is
+	// meaningful when TimeStatus is TimeToBeDefined.
+	Start      time.Time
+	TimeStatus TimeStatus
+}
+
+// TimeStatus tells whether an event's kickoff time is known.
+type TimeStatus int
+
+const (
+	// TimeConfirmed means DateTime carries the actual kickoff time.
+	TimeConfirmed TimeStatus = iota
+	// TimeToBeDefined means only the date is known; the time part of
+	// DateTime is meaningless.
+	TimeToBeDefined
+)
+
+func (s TimeStatus) String() string {
+	switch s {
+	case TimeConfirmed:
+		return "confirmed"
+	case TimeToBeDefined:
+		return "to-be-defined"
 	}
-	req, err := http.NewRequest("POST", baseURL, strings.NewReader(body.Encode()))
-	if err != nil {
-		return nil, fmt.Errorf("could not build POST request object for %s: %w", baseURL, err)
+	return fmt.Sprintf("TimeStatus(%d)", int(s))
+}
+
+// ExtractOption customizes ExtractEvents.
+type ExtractOption func(*extractOptions)
+
+type extractOptions struct {
+	finished    bool
+	lenient     bool
+	startDate   time.Time
+	endDate     time.Time
+	clock       clock.Clock
+	selectors   *Selectors
+	tournaments *registry.Tournaments
+	teams       *registry.Teams
+	stadiums    *registry.Stadiums
+}
+
+// WithFinished keeps finished matches, along with their final score.
+func WithFinished() ExtractOption {
+	return func(o *extractOptions) {
+		o.finished = true
 	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("Accept", "text/html")
-	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/115.0")
+}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("could not make POST request to %s: %w", baseURL, err)
+// WithLenient skips matches that cannot be parsed instead of failing. The
+// events that could be parsed are returned along with a ParseErrors error.
+func WithLenient() ExtractOption {
+	return func(o *extractOptions) {
+		o.lenient = true
 	}
-	defer resp.Body.Close()
+}
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("unexpected status code from %s: %d", baseURL, resp.StatusCode)
+// WithSelectors reads the page with the given selector profile instead of the
+// default one.
+func WithSelectors(s *Selectors) ExtractOption {
+	return func(o *extractOptions) {
+		o.selectors = s
 	}
+}
 
-	events, err := ExtractEvents(resp.Body, startDate.Location())
-	if err != nil {
-		return nil, err
+// WithTournaments normalizes tournament names with the given registry instead
+// of the default one.
+func WithTournaments(t *registry.Tournaments) ExtractOption {
+	return func(o *extractOptions) {
+		o.tournaments = t
+	}
+}
+
+// WithTeams normalizes team names with the given registry instead of the
+// default one. Names it does not know are recorded in it.
+func WithTeams(t *registry.Teams) ExtractOption {
+	return func(o *extractOptions) {
+		o.teams = t
 	}
+}
 
-	return events, nil
+// WithStadiums normalizes stadium names with the given registry instead of
+// the default one.
+func WithStadiums(s *registry.Stadiums) ExtractOption {
+	return func(o *extractOptions) {
+		o.stadiums = s
+	}
+}
+
+// WithClock sets the clock used to tell the current year when no date range
+// is given.
+func WithClock(c clock.Clock) ExtractOption {
+	return func(o *extractOptions) {
+		o.clock = c
+	}
+}
+
+// WithDateRange sets the window the agenda was requested for. The agenda lists
+// dates without a year, so each one is placed in the year that makes it fall
+// within the window. Without it, the current year is assumed.
+func WithDateRange(startDate, endDate time.Time) ExtractOption {
+	return func(o *extractOptions) {
+		o.startDate = startDate
+		o.endDate = endDate
+	}
+}
+
+// FetchAll fetches events from the agenda page with the default Fetcher settings.
+func FetchAll(startDate, endDate time.Time) ([]Event, error) {
+	return NewFetcher(nil).Fetch(context.Background(), startDate, endDate)
+}
+
+func newExtractOptions(opts []ExtractOption) extractOptions {
+	var o extractOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.clock = clock.OrSystem(o.clock)
+	if o.selectors == nil {
+		o.selectors = DefaultSelectors()
+	}
+	if o.tournaments == nil {
+		o.tournaments = registry.DefaultTournaments()
+	}
+	if o.teams == nil {
+		o.teams = registry.DefaultTeams()
+	}
+	if o.stadiums == nil {
+		o.stadiums = registry.DefaultStadiums()
+	}
+	return o
 }
 
-func ExtractEvents(r io.Reader, loc *time.Location) ([]Event, error) {
+func ExtractEvents(r io.Reader, loc *time.Location, opts ...ExtractOption) ([]Event, error) {
+	o := newExtractOptions(opts)
+
 	doc, err := htmlquery.Parse(r)
 	if err != nil {
 		return nil, fmt.Errorf("ExtractEvents(): could not parse input: %w", err)
 	}
 
-	if !isCalendarPage(doc) {
+	sel := o.selectors
+	if !isCalendarPage(doc, sel) {
 		return nil, fmt.Errorf("ExtractEvents(): invalid html page: %w", ErrUnexpectedInput)
 	}
 
-	nodes, err := htmlquery.QueryAll(doc, `
-	//section[@class="agenda-partidas"]
-	//div[contains(concat(" ",normalize-space(@class)," ")," partida ") and not(contains(@class, "partida-finalizada"))]
-	`)
+	expr := sel.UpcomingMatches
+	if o.finished {
+		expr = sel.Matches
+	}
+	nodes, err := htmlquery.QueryAll(doc, expr)
 	if err != nil {
-		return nil, fmt.Errorf("ExtractEvents(): could not query main section node: %w", err)
+		return nil, fmt.Errorf("ExtractEvents(): could not query match nodes: %w", err)
 	}
 
-	events := make([]Event, len(nodes))
+	var (
+		events []Event
+		errs   ParseErrors
+	)
 	for i, node := range nodes {
-		var event Event
-
-		div, err := htmlquery.Query(node, `//div[@class="partida-data"]`)
-		switch {
-		case err != nil:
-			return nil, fmt.Errorf("ExtractEvents(): could not query 'div.partida-data': %w", err)
-		case div == nil:
-			return nil, fmt.Errorf("ExtractEvents(): missing expected 'div.partida-data' node: %w", ErrUnexpectedInput)
+		event, err := o.parseNode(node, loc)
+		if err != nil {
+			err.Index = i
+			if !o.lenient {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
 		}
+		events = append(events, event)
+	}
+
+	// The agenda lists events in descending order
+	slices.Reverse(events)
+
+	if len(errs) > 0 {
+		return events, errs
+	}
+	return events, nil
+}
 
-		stadium := htmlquery.InnerText(div.LastChild)
-		event.Stadium = normalizeStadium(stadium)
+// parseNode extracts a single event from a match node.
+func (o extractOptions) parseNode(node *html.Node, loc *time.Location) (Event, *ParseError) {
+	var event Event
+	sel := o.selectors
 
-		dateTime := htmlquery.InnerText(div.FirstChild.NextSibling)
-		event.DateTime = parseDateTime(dateTime, loc)
-		if event.DateTime.IsZero() {
-			return nil, fmt.Errorf(`ExtractEvents(): unexpected date/time format: "%s": %w`, dateTime, ErrUnexpectedInput)
+	text, err := htmlquery.Query(node, sel.Stadium)
+	switch {
+	case err != nil:
+		return Event{}, newParseError("stadium", "", sel.Stadium, fmt.Errorf("could not query stadium: %w", err))
+	case text == nil:
+		return Event{}, newParseError("stadium", "", sel.Stadium,
+			fmt.Errorf("missing expected stadium node: %w", ErrUnexpectedInput))
+	}
+	event.Stadium = o.stadiums.Normalize(htmlquery.InnerText(text))
+
+	span, err := htmlquery.Query(node, sel.DateTime)
+	switch {
+	case err != nil:
+		return Event{}, newParseError("datetime", "", sel.DateTime, fmt.Errorf("could not query date/time: %w", err))
+	case span == nil:
+		return Event{}, newParseError("datetime", "", sel.DateTime,
+			fmt.Errorf("missing expected date/time node: %w", ErrUnexpectedInput))
+	}
+	dateTime := htmlquery.InnerText(span)
+	parsed, status := parseDateTime(dateTime, loc)
+	if parsed.IsZero() {
+		return Event{}, newParseError("datetime", dateTime, sel.DateTime,
+			fmt.Errorf("unexpected date/time format: %w", ErrUnexpectedInput))
+	}
+	date, ok := o.inferYear(parsed, o.parseStatus(node, false) == StatusFinished)
+	if !ok {
+		return Event{}, newParseError("datetime", dateTime, sel.DateTime,
+			fmt.Errorf("no year places it within %s - %s: %w",
+				o.startDate.Format(time.DateOnly), o.endDate.Format(time.DateOnly), ErrDateOutOfRange))
+	}
+	event.DateTime, event.TimeStatus = date, status
+	event.LocalDateTime = o.localDateTime(event.Stadium, date, status)
+
+	div, err := htmlquery.Query(node, sel.Tournament)
+	switch {
+	case err != nil:
+		return Event{}, newParseError("tournament", "", sel.Tournament, fmt.Errorf("could not query tournament: %w", err))
+	case div == nil:
+		return Event{}, newParseError("tournament", "", sel.Tournament,
+			fmt.Errorf("missing expected tournament node: %w", ErrUnexpectedInput))
+	}
+	event.Tournament = o.tournaments.Normalize(htmlquery.InnerText(div))
+
+	teams, err := htmlquery.QueryAll(node, sel.Teams)
+	switch {
+	case err != nil:
+		return Event{}, newParseError("teams", "", sel.Teams, fmt.Errorf("could not query teams: %w", err))
+	case teams == nil:
+		return Event{}, newParseError("teams", "", sel.Teams,
+			fmt.Errorf("missing expected team nodes: %w", ErrUnexpectedInput))
+	case len(teams) != 2:
+		return Event{}, newParseError("teams", o.teamsText(teams), sel.Teams,
+			fmt.Errorf("missing expected 2 team nodes: %w", ErrUnexpectedInput))
+	}
+	event.HomeTeam = o.teams.Normalize(htmlquery.SelectAttr(teams[0], sel.TeamNameAttr))
+	event.AwayTeam = o.teams.Normalize(htmlquery.SelectAttr(teams[1], sel.TeamNameAttr))
+
+	broadcasts, err := htmlquery.QueryAll(node, sel.Broadcasts)
+	if err != nil {
+		return Event{}, newParseError("broadcasts", "", sel.Broadcasts, fmt.Errorf("could not query broadcasts: %w", err))
+	}
+	for _, b := range broadcasts {
+		name := normalizeBroadcast(nodeName(b))
+		if name != "" && !slices.Contains(event.Broadcasts, name) {
+			event.Broadcasts = append(event.Broadcasts, name)
 		}
+	}
+
+	link, err := htmlquery.Query(node, sel.MatchLink)
+	if err != nil {
+		return Event{}, newParseError("match_link", "", sel.MatchLink, fmt.Errorf("could not query match link: %w", err))
+	}
+	if link != nil {
+		event.MatchURL = strings.TrimSpace(htmlquery.SelectAttr(link, "href"))
+	}
 
-		div, err = htmlquery.Query(node, `//div[@class="partida-campeonato"]`)
-		switch {
-		case err != nil:
-			return nil, fmt.Errorf("ExtractEvents(): could not query 'div.partida-campeonato': %w", err)
-		case div == nil:
-			return nil, fmt.Errorf("ExtractEvents(): missing expected 'div.partida-campeonato' node: %w", ErrUnexpectedInput)
+	tickets, perr := o.parseTicketSale(node, event.DateTime)
+	if perr != nil {
+		return Event{}, perr
+	}
+	event.TicketSale = tickets
+
+	if o.finished {
+		spans, err := htmlquery.QueryAll(node, sel.Score)
+		if err != nil {
+			return Event{}, newParseError("score", "", sel.Score, fmt.Errorf("could not query score: %w", err))
 		}
-		event.Tournament = normalizeTournament(htmlquery.InnerText(div))
-
-		abbr, err := htmlquery.QueryAll(node, `//div[@class="partida-placar"]//abbr[@title]`)
-		switch {
-		case err != nil:
-			return nil, fmt.Errorf("ExtractEvents(): could not query 'abbr[@title]': %w", err)
-		case abbr == nil:
-			return nil, fmt.Errorf("ExtractEvents(): missing expected 'abbr[@title]' nodes: %w", ErrUnexpectedInput)
-		case len(abbr) != 2:
-			return nil, fmt.Errorf("ExtractEvents(): missing expected 2 'abbr[@title]' nodes: %w", ErrUnexpectedInput)
+		if len(spans) == 2 {
+			home, homePen, okHome := parseScore(htmlquery.InnerText(spans[0]))
+			away, awayPen, okAway := parseScore(htmlquery.InnerText(spans[1]))
+			if okHome && okAway {
+				event.HomeScore, event.AwayScore = home, away
+				event.HomePenalties, event.AwayPenalties = homePen, awayPen
+			}
 		}
-		event.HomeTeam = normalizeTeam(htmlquery.SelectAttr(abbr[0], "title"))
-		event.AwayTeam = normalizeTeam(htmlquery.SelectAttr(abbr[1], "title"))
+	}
+	event.Status = o.parseStatus(node, event.HomeScore != nil)
 
-		events[len(events)-(i+1)] = event
+	return event, nil
+}
+
+func (o extractOptions) teamsText(nodes []*html.Node) string {
+	titles := make([]string, len(nodes))
+	for i, node := range nodes {
+		titles[i] = htmlquery.SelectAttr(node, o.selectors.TeamNameAttr)
 	}
+	return strings.Join(titles, ", ")
+}
 
-	return events, nil
+var ticketSaleRegexp = regexp.MustCompile(`(\d{2}/\d{2})(?:\s+às\s+(\S+))?`)
+
+// parseTicketSale extracts the ticket sale of a match, placing its start in
+// the latest year that does not go past the match date.
+func (o extractOptions) parseTicketSale(node *html.Node, matchDate time.Time) (*TicketSale, *ParseError) {
+	sel := o.selectors
+
+	link, err := htmlquery.Query(node, sel.TicketLink)
+	if err != nil {
+		return nil, newParseError("ticket_link", "", sel.TicketLink, fmt.Errorf("could not query ticket link: %w", err))
+	}
+	text, err := htmlquery.Query(node, sel.TicketSale)
+	if err != nil {
+		return nil, newParseError("ticket_sale", "", sel.TicketSale, fmt.Errorf("could not query ticket sale: %w", err))
+	}
+	if link == nil && text == nil {
+		return nil, nil
+	}
+
+	var tickets TicketSale
+	if link != nil {
+		tickets.URL = strings.TrimSpace(htmlquery.SelectAttr(link, "href"))
+	}
+	// Texts without a date, such as "Ingressos esgotados", announce no start
+	if text != nil {
+		raw := htmlquery.InnerText(text)
+		m := ticketSaleRegexp.FindStringSubmatch(raw)
+		if m == nil {
+			return &tickets, nil
+		}
+		input := m[1]
+		if m[2] != "" {
+			input += " às " + m[2]
+		}
+		start, status := parseDateTime(input, matchDate.Location())
+		if start.IsZero() {
+			return nil, newParseError("ticket_sale", raw, sel.TicketSale,
+				fmt.Errorf("unexpected ticket sale date/time format: %w", ErrUnexpectedInput))
+		}
+		start = time.Date(matchDate.Year(), start.Month(), start.Day(), start.Hour(), start.Minute(), 0, 0, start.Location())
+		if startOfDay(start).After(matchDate) {
+			start = start.AddDate(-1, 0, 0)
+		}
+		tickets.Start, tickets.TimeStatus = start, status
+	}
+	return &tickets, nil
+}
+
+// nodeName returns the text of a node, falling back to the alt or title
+// attribute of the node or its descendants for image-only content.
+func nodeName(node *html.Node) string {
+	if text := strings.TrimSpace(htmlquery.InnerText(node)); text != "" {
+		return text
+	}
+	for _, attr := range []string{"alt", "title"} {
+		if n := htmlquery.FindOne(node, "descendant-or-self::*[@"+attr+"]"); n != nil {
+			return htmlquery.SelectAttr(n, attr)
+		}
+	}
+	return ""
 }
 
-func isCalendarPage(doc *html.Node) bool {
-	node, _ := htmlquery.Query(doc, "//title")
+func isCalendarPage(doc *html.Node, sel *Selectors) bool {
+	node, _ := htmlquery.Query(doc, sel.Title)
+	if node == nil {
+		return false
+	}
 	title := strings.ToLower(htmlquery.InnerText(node))
-	return strings.HasPrefix(title, "calendário de jogos")
+	return strings.HasPrefix(title, strings.ToLower(sel.TitlePrefix))
 }
 
-func parseDateTime(input string, loc *time.Location) time.Time {
-	input = strings.ToLower(input)
-	now := time.Now().In(loc)
+var dateTimeLayouts = []struct {
+	layout string
+	status TimeStatus
+}{
 	// Date and time (21:00 format)
-	t, err := time.ParseInLocation("02/01 às 15:04-2006", fmt.Sprintf("%s-%d", input, now.Year()), loc)
+	{"02/01 às 15:04-2006", TimeConfirmed},
 	// Date and time (21h format)
-	if err != nil {
-		t, err = time.ParseInLocation("02/01 às 15h04-2006", fmt.Sprintf("%s-%d", input, now.Year()), loc)
-	}
+	{"02/01 às 15h04-2006", TimeConfirmed},
 	// Date only
-	if err != nil {
-		t, err = time.ParseInLocation("02/01-2006", fmt.Sprintf("%s-%d", input, now.Year()), loc)
-	}
+	{"02/01-2006", TimeToBeDefined},
 	// Date only ("a definir" format)
-	if err != nil {
-		t, _ = time.ParseInLocation("02/01 às a definir-2006", fmt.Sprintf("%s-%d", input, now.Year()), loc)
+	{"02/01 às a definir-2006", TimeToBeDefined},
+}
+
+// parseDateTime parses the agenda date/time and reports whether the kickoff
+// time is known. As the agenda omits the year, the result is placed in 2000, a
+// leap year so that 29/02 parses; see inferYear. It returns the zero time when
+// no format matches.
+func parseDateTime(input string, loc *time.Location) (time.Time, TimeStatus) {
+	input = strings.ToLower(input)
+	for _, l := range dateTimeLayouts {
+		t, err := time.ParseInLocation(l.layout, input+"-2000", loc)
+		if err == nil {
+			return t, l.status
+		}
 	}
-	return t
+	return time.Time{}, TimeToBeDefined
 }
 
-func normalizeStadium(input string) string {
-	return strings.TrimSpace(input)
+// inferYear moves t, as returned by parseDateTime, to the first year in which
+// its date falls within the requested window, or to the current year when no
+// window was given. Finished matches are listed before the window starts, so
+// they get the latest year that does not go past its end instead.
+func (o extractOptions) inferYear(t time.Time, finished bool) (time.Time, bool) {
+	withYear := func(year int) (time.Time, bool) {
+		c := time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location())
+		// Rule out 29/02 being normalized to 01/03 in non-leap years
+		return c, c.Month() == t.Month()
+	}
+
+	if o.startDate.IsZero() || o.endDate.IsZero() {
+		return withYear(o.clock.Now().In(t.Location()).Year())
+	}
+
+	start := startOfDay(o.startDate.In(t.Location()))
+	end := startOfDay(o.endDate.In(t.Location())).AddDate(0, 0, 1)
+	if finished {
+		// Look back far enough for 29/02 to find a leap year
+		for year := end.Year(); year >= end.Year()-4; year-- {
+			if c, ok := withYear(year); ok && startOfDay(c).Before(end) {
+				return c, true
+			}
+		}
+		return time.Time{}, false
+	}
+	for year := start.Year(); year <= end.Year(); year++ {
+		c, ok := withYear(year)
+		if ok && !startOfDay(c).Before(start) && startOfDay(c).Before(end) {
+			return c, true
+		}
+	}
+	return time.Time{}, false
 }
 
-func normalizeTournament(input string) string {
-	input = strings.TrimSpace(input)
-	tournament := strings.ToLower(input)
-	switch {
-	case strings.Contains(tournament, "libertadores"):
-		return "Libertadores"
-	case strings.Contains(tournament, "brasileir"):
-		return "Brasileirão"
-	case strings.Contains(tournament, "do brasil"):
-		return "Copa do Brasil"
-	case strings.Contains(tournament, "mineiro"):
-		return "Campeonato Mineiro"
-	case strings.Contains(tournament, "americana"):
-		return "Sul-Americana"
-	}
-	return input
-}
-
-func normalizeTeam(input string) string {
-	return strings.TrimSpace(input)
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+var scoreRegexp = regexp.MustCompile(`^(?:\((\d+)\)\s*)?(\d+)(?:\s*\((\d+)\))?$`)
+
+// parseScore parses a side of the scoreline, such as "2", "1 (4)" or "(4) 1",
+// where the number in parentheses is the penalty shootout score.
+func parseScore(input string) (*int, *int, bool) {
+	m := scoreRegexp.FindStringSubmatch(strings.TrimSpace(input))
+	if m == nil {
+		return nil, nil, false
+	}
+
+	score, _ := strconv.Atoi(m[2])
+	var penalties *int
+	for _, p := range []string{m[1], m[3]} {
+		if p != "" {
+			n, _ := strconv.Atoi(p)
+			penalties = &n
+		}
+	}
+	return &score, penalties, true
+}
+
+// localDateTime returns the kickoff at the given stadium in its time zone.
+func (o extractOptions) localDateTime(stadium string, date time.Time, status TimeStatus) time.Time {
+	if status != TimeConfirmed {
+		return time.Time{}
+	}
+	venue, ok := o.stadiums.Lookup(stadium)
+	if !ok {
+		return time.Time{}
+	}
+	loc := venue.Location()
+	if loc == nil {
+		return time.Time{}
+	}
+	return date.In(loc)
+}
+
+func normalizeBroadcast(input string) string {
+	return strings.Join(strings.Fields(input), " ")
 }
@@ -4,11 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/romanodesouza/galendario/internal/clock"
 	"github.com/romanodesouza/galendario/internal/event"
+	"github.com/romanodesouza/galendario/internal/registry"
 )
 
 func TestExtractEvents(t *testing.T) {
@@ -17,12 +20,13 @@ func TestExtractEvents(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	now := time.Now().In(loc)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, loc)
 	year := now.Year()
 
 	tests := []struct {
 		name    string
 		input   string
+		opts    []event.ExtractOption
 		want    []event.Event
 		wantErr error
 	}{
@@ -31,25 +35,28 @@ func TestExtractEvents(t *testing.T) {
 			input: "agenda.html",
 			want: []event.Event{
 				{
-					Tournament: "Campeonato Mineiro",
-					Stadium:    "Mário Helênio",
-					DateTime:   time.Date(year, 1, 19, 16, 0, 0, 0, loc),
-					HomeTeam:   "Aymorés",
-					AwayTeam:   "Atlético",
+					Tournament:    "Campeonato Mineiro",
+					Stadium:       "Mário Helênio",
+					DateTime:      time.Date(year, 1, 19, 16, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 1, 19, 16, 0, 0, 0, loc),
+					HomeTeam:      "Aymorés",
+					AwayTeam:      "Atlético",
 				},
 				{
-					Tournament: "Copa do Brasil",
-					Stadium:    "Arena MRV",
-					DateTime:   time.Date(year, 4, 30, 21, 30, 0, 0, loc),
-					HomeTeam:   "Atlético",
-					AwayTeam:   "Sport",
+					Tournament:    "Copa do Brasil",
+					Stadium:       "Arena MRV",
+					DateTime:      time.Date(year, 4, 30, 21, 30, 0, 0, loc),
+					LocalDateTime: time.Date(year, 4, 30, 21, 30, 0, 0, loc),
+					HomeTeam:      "Atlético",
+					AwayTeam:      "Sport",
 				},
 				{
-					Tournament: "Copa do Brasil",
-					Stadium:    "Arena Pernambuco",
-					DateTime:   time.Date(year, 5, 22, 19, 0, 0, 0, loc),
-					HomeTeam:   "Sport",
-					AwayTeam:   "Atlético",
+					Tournament:    "Copa do Brasil",
+					Stadium:       "Arena Pernambuco",
+					DateTime:      time.Date(year, 5, 22, 19, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 22, 19, 0, 0, 0, loc),
+					HomeTeam:      "Sport",
+					AwayTeam:      "Atlético",
 				},
 			},
 			wantErr: nil,
@@ -59,25 +66,200 @@ func TestExtractEvents(t *testing.T) {
 			input: "agenda_finished_unfinished.html",
 			want: []event.Event{
 				{
-					Tournament: "Libertadores",
-					Stadium:    "Gigante de Arroyito",
-					DateTime:   time.Date(year, 5, 7, 19, 0, 0, 0, loc),
-					HomeTeam:   "Rosario Central",
-					AwayTeam:   "Atlético",
+					Tournament:    "Libertadores",
+					Stadium:       "Gigante de Arroyito",
+					DateTime:      time.Date(year, 5, 7, 19, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 7, 19, 0, 0, 0, loc),
+					HomeTeam:      "Rosario Central",
+					AwayTeam:      "Atlético",
+				},
+				{
+					Tournament:    "Libertadores",
+					Stadium:       "Campeón del Siglo",
+					DateTime:      time.Date(year, 5, 14, 19, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 14, 19, 0, 0, 0, loc),
+					HomeTeam:      "Peñarol",
+					AwayTeam:      "Atlético",
+				},
+				{
+					Tournament:    "Libertadores",
+					Stadium:       "Arena MRV",
+					DateTime:      time.Date(year, 5, 28, 19, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 28, 19, 0, 0, 0, loc),
+					HomeTeam:      "Atlético",
+					AwayTeam:      "Caracas",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "it should extract finished events with their final score when asked to",
+			input: "agenda_finished_scores.html",
+			opts:  []event.ExtractOption{event.WithFinished()},
+			want: []event.Event{
+				{
+					Tournament:    "Libertadores",
+					Stadium:       "Olímpico de la UCV",
+					DateTime:      time.Date(year, 4, 4, 0, 0, 0, 0, loc),
+					TimeStatus:    event.TimeToBeDefined,
+					Status:        event.StatusFinished,
+					HomeTeam:      "Caracas",
+					AwayTeam:      "Atlético",
+					HomeScore:     intPtr(1),
+					AwayScore:     intPtr(1),
+					HomePenalties: intPtr(2),
+					AwayPenalties: intPtr(4),
+					MatchURL:      "https://atletico.com.br/partida/caracas-x-atletico/",
 				},
 				{
 					Tournament: "Libertadores",
-					Stadium:    "Campeón del Siglo",
-					DateTime:   time.Date(year, 5, 14, 19, 0, 0, 0, loc),
-					HomeTeam:   "Peñarol",
-					AwayTeam:   "Atlético",
+					Stadium:    "Arena MRV",
+					DateTime:   time.Date(year, 4, 10, 0, 0, 0, 0, loc),
+					TimeStatus: event.TimeToBeDefined,
+					Status:     event.StatusFinished,
+					HomeTeam:   "Atlético",
+					AwayTeam:   "Rosario Central",
+					HomeScore:  intPtr(2),
+					AwayScore:  intPtr(1),
+					MatchURL:   "https://atletico.com.br/partida/atletico-x-rosario-central/",
 				},
 				{
 					Tournament: "Libertadores",
 					Stadium:    "Arena MRV",
-					DateTime:   time.Date(year, 5, 28, 19, 0, 0, 0, loc),
+					DateTime:   time.Date(year, 4, 23, 0, 0, 0, 0, loc),
+					TimeStatus: event.TimeToBeDefined,
+					Status:     event.StatusFinished,
 					HomeTeam:   "Atlético",
-					AwayTeam:   "Caracas",
+					AwayTeam:   "Peñarol",
+					HomeScore:  intPtr(3),
+					AwayScore:  intPtr(2),
+					MatchURL:   "https://atletico.com.br/partida/atletico-x-penarol/",
+				},
+				{
+					Tournament:    "Libertadores",
+					Stadium:       "Gigante de Arroyito",
+					DateTime:      time.Date(year, 5, 7, 19, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 7, 19, 0, 0, 0, loc),
+					HomeTeam:      "Rosario Central",
+					AwayTeam:      "Atlético",
+				},
+				{
+					Tournament:    "Libertadores",
+					Stadium:       "Campeón del Siglo",
+					DateTime:      time.Date(year, 5, 14, 19, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 14, 19, 0, 0, 0, loc),
+					HomeTeam:      "Peñarol",
+					AwayTeam:      "Atlético",
+				},
+				{
+					Tournament:    "Libertadores",
+					Stadium:       "Arena MRV",
+					DateTime:      time.Date(year, 5, 28, 19, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 28, 19, 0, 0, 0, loc),
+					HomeTeam:      "Atlético",
+					AwayTeam:      "Caracas",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "it should extract the status of each event from its classes and labels",
+			input: "agenda_status.html",
+			want: []event.Event{
+				{
+					Tournament:    "Campeonato Mineiro",
+					Stadium:       "Mário Helênio",
+					DateTime:      time.Date(year, 1, 19, 16, 0, 0, 0, loc),
+					Status:        event.StatusLive,
+					LocalDateTime: time.Date(year, 1, 19, 16, 0, 0, 0, loc),
+					HomeTeam:      "Aymorés",
+					AwayTeam:      "Atlético",
+				},
+				{
+					Tournament:    "Copa do Brasil",
+					Stadium:       "Arena MRV",
+					DateTime:      time.Date(year, 4, 30, 21, 30, 0, 0, loc),
+					Status:        event.StatusPostponed,
+					LocalDateTime: time.Date(year, 4, 30, 21, 30, 0, 0, loc),
+					HomeTeam:      "Atlético",
+					AwayTeam:      "Sport",
+				},
+				{
+					Tournament:    "Copa do Brasil",
+					Stadium:       "Arena Pernambuco",
+					DateTime:      time.Date(year, 5, 22, 19, 0, 0, 0, loc),
+					Status:        event.StatusCancelled,
+					LocalDateTime: time.Date(year, 5, 22, 19, 0, 0, 0, loc),
+					HomeTeam:      "Sport",
+					AwayTeam:      "Atlético",
+				},
+			},
+		},
+		{
+			name:  "it should extract the broadcasters of each event",
+			input: "agenda_broadcasts.html",
+			want: []event.Event{
+				{
+					Tournament:    "Campeonato Mineiro",
+					Stadium:       "Mário Helênio",
+					DateTime:      time.Date(year, 1, 19, 16, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 1, 19, 16, 0, 0, 0, loc),
+					HomeTeam:      "Aymorés",
+					AwayTeam:      "Atlético",
+				},
+				{
+					Tournament:    "Copa do Brasil",
+					Stadium:       "Arena MRV",
+					DateTime:      time.Date(year, 4, 30, 21, 30, 0, 0, loc),
+					LocalDateTime: time.Date(year, 4, 30, 21, 30, 0, 0, loc),
+					HomeTeam:      "Atlético",
+					AwayTeam:      "Sport",
+					Broadcasts:    []string{"Prime Video"},
+				},
+				{
+					Tournament:    "Copa do Brasil",
+					Stadium:       "Arena Pernambuco",
+					DateTime:      time.Date(year, 5, 22, 19, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 22, 19, 0, 0, 0, loc),
+					HomeTeam:      "Sport",
+					AwayTeam:      "Atlético",
+					Broadcasts:    []string{"Globo", "Premiere"},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "it should extract ticket sale links and opening dates",
+			input: "agenda_tickets.html",
+			want: []event.Event{
+				{
+					Tournament:    "Campeonato Mineiro",
+					Stadium:       "Mário Helênio",
+					DateTime:      time.Date(year, 1, 19, 16, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 1, 19, 16, 0, 0, 0, loc),
+					HomeTeam:      "Aymorés",
+					AwayTeam:      "Atlético",
+					TicketSale:    &event.TicketSale{URL: "https://ingressos.galonaveia.com.br/"},
+				},
+				{
+					Tournament:    "Copa do Brasil",
+					Stadium:       "Arena MRV",
+					DateTime:      time.Date(year, 4, 30, 21, 30, 0, 0, loc),
+					LocalDateTime: time.Date(year, 4, 30, 21, 30, 0, 0, loc),
+					HomeTeam:      "Atlético",
+					AwayTeam:      "Sport",
+					TicketSale: &event.TicketSale{
+						URL:   "https://ingressos.galonaveia.com.br/eventos/atletico-x-sport",
+						Start: time.Date(year, 4, 24, 10, 0, 0, 0, loc),
+					},
+				},
+				{
+					Tournament:    "Copa do Brasil",
+					Stadium:       "Arena Pernambuco",
+					DateTime:      time.Date(year, 5, 22, 19, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 22, 19, 0, 0, 0, loc),
+					HomeTeam:      "Sport",
+					AwayTeam:      "Atlético",
 				},
 			},
 			wantErr: nil,
@@ -93,23 +275,26 @@ func TestExtractEvents(t *testing.T) {
 			input: "agenda_dates_without_time.html",
 			want: []event.Event{
 				{
-					Tournament: "Brasileirão",
-					Stadium:    "Arena MRV",
-					DateTime:   time.Date(year, 5, 11, 21, 0, 0, 0, loc),
-					HomeTeam:   "Atlético",
-					AwayTeam:   "Grêmio",
+					Tournament:    "Brasileirão",
+					Stadium:       "Arena MRV",
+					DateTime:      time.Date(year, 5, 11, 21, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 11, 21, 0, 0, 0, loc),
+					HomeTeam:      "Atlético",
+					AwayTeam:      "Grêmio",
 				},
 				{
-					Tournament: "Brasileirão",
-					Stadium:    "Arena MRV",
-					DateTime:   time.Date(year, 5, 19, 16, 0, 0, 0, loc),
-					HomeTeam:   "Atlético",
-					AwayTeam:   "Bahia",
+					Tournament:    "Brasileirão",
+					Stadium:       "Arena MRV",
+					DateTime:      time.Date(year, 5, 19, 16, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 19, 16, 0, 0, 0, loc),
+					HomeTeam:      "Atlético",
+					AwayTeam:      "Bahia",
 				},
 				{
 					Tournament: "Brasileirão",
 					Stadium:    "Arena MRV",
 					DateTime:   time.Date(year, 9, 29, 0, 0, 0, 0, loc),
+					TimeStatus: event.TimeToBeDefined,
 					HomeTeam:   "Atlético",
 					AwayTeam:   "Vitória",
 				},
@@ -117,6 +302,7 @@ func TestExtractEvents(t *testing.T) {
 					Tournament: "Brasileirão",
 					Stadium:    "Castelão",
 					DateTime:   time.Date(year, 10, 5, 0, 0, 0, 0, loc),
+					TimeStatus: event.TimeToBeDefined,
 					HomeTeam:   "Fortaleza",
 					AwayTeam:   "Atlético",
 				},
@@ -128,23 +314,26 @@ func TestExtractEvents(t *testing.T) {
 			input: "agenda_dates_without_time_multiple_formats.html",
 			want: []event.Event{
 				{
-					Tournament: "Brasileirão",
-					Stadium:    "Arena MRV",
-					DateTime:   time.Date(year, 5, 11, 21, 0, 0, 0, loc),
-					HomeTeam:   "Atlético",
-					AwayTeam:   "Grêmio",
+					Tournament:    "Brasileirão",
+					Stadium:       "Arena MRV",
+					DateTime:      time.Date(year, 5, 11, 21, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 11, 21, 0, 0, 0, loc),
+					HomeTeam:      "Atlético",
+					AwayTeam:      "Grêmio",
 				},
 				{
-					Tournament: "Brasileirão",
-					Stadium:    "Arena MRV",
-					DateTime:   time.Date(year, 5, 19, 16, 0, 0, 0, loc),
-					HomeTeam:   "Atlético",
-					AwayTeam:   "Bahia",
+					Tournament:    "Brasileirão",
+					Stadium:       "Arena MRV",
+					DateTime:      time.Date(year, 5, 19, 16, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 19, 16, 0, 0, 0, loc),
+					HomeTeam:      "Atlético",
+					AwayTeam:      "Bahia",
 				},
 				{
 					Tournament: "Brasileirão",
 					Stadium:    "Arena MRV",
 					DateTime:   time.Date(year, 9, 29, 0, 0, 0, 0, loc),
+					TimeStatus: event.TimeToBeDefined,
 					HomeTeam:   "Atlético",
 					AwayTeam:   "Vitória",
 				},
@@ -152,22 +341,25 @@ func TestExtractEvents(t *testing.T) {
 					Tournament: "Brasileirão",
 					Stadium:    "Castelão",
 					DateTime:   time.Date(year, 10, 5, 0, 0, 0, 0, loc),
+					TimeStatus: event.TimeToBeDefined,
 					HomeTeam:   "Fortaleza",
 					AwayTeam:   "Atlético",
 				},
 				{
-					Tournament: "Libertadores",
-					Stadium:    "Nuevo Gasómetro",
-					DateTime:   time.Date(year, 8, 13, 21, 30, 0, 0, loc),
-					HomeTeam:   "San Lorenzo",
-					AwayTeam:   "Atlético",
+					Tournament:    "Libertadores",
+					Stadium:       "Nuevo Gasómetro",
+					DateTime:      time.Date(year, 8, 13, 21, 30, 0, 0, loc),
+					LocalDateTime: time.Date(year, 8, 13, 21, 30, 0, 0, loc),
+					HomeTeam:      "San Lorenzo",
+					AwayTeam:      "Atlético",
 				},
 				{
-					Tournament: "Sul-Americana",
-					Stadium:    "Arena MRV",
-					DateTime:   time.Date(year, 5, 29, 21, 30, 0, 0, loc),
-					HomeTeam:   "Atlético",
-					AwayTeam:   "Cienciano",
+					Tournament:    "Sul-Americana",
+					Stadium:       "Arena MRV",
+					DateTime:      time.Date(year, 5, 29, 21, 30, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 29, 21, 30, 0, 0, loc),
+					HomeTeam:      "Atlético",
+					AwayTeam:      "Cienciano",
 				},
 			},
 			wantErr: nil,
@@ -177,23 +369,26 @@ func TestExtractEvents(t *testing.T) {
 			input: "agenda_a_definir_format.html",
 			want: []event.Event{
 				{
-					Tournament: "Brasileirão",
-					Stadium:    "Arena MRV",
-					DateTime:   time.Date(year, 5, 11, 21, 0, 0, 0, loc),
-					HomeTeam:   "Atlético",
-					AwayTeam:   "Grêmio",
+					Tournament:    "Brasileirão",
+					Stadium:       "Arena MRV",
+					DateTime:      time.Date(year, 5, 11, 21, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 11, 21, 0, 0, 0, loc),
+					HomeTeam:      "Atlético",
+					AwayTeam:      "Grêmio",
 				},
 				{
-					Tournament: "Brasileirão",
-					Stadium:    "Arena MRV",
-					DateTime:   time.Date(year, 5, 19, 16, 0, 0, 0, loc),
-					HomeTeam:   "Atlético",
-					AwayTeam:   "Bahia",
+					Tournament:    "Brasileirão",
+					Stadium:       "Arena MRV",
+					DateTime:      time.Date(year, 5, 19, 16, 0, 0, 0, loc),
+					LocalDateTime: time.Date(year, 5, 19, 16, 0, 0, 0, loc),
+					HomeTeam:      "Atlético",
+					AwayTeam:      "Bahia",
 				},
 				{
 					Tournament: "Brasileirão",
 					Stadium:    "Arena MRV",
 					DateTime:   time.Date(year, 9, 29, 0, 0, 0, 0, loc),
+					TimeStatus: event.TimeToBeDefined,
 					HomeTeam:   "Atlético",
 					AwayTeam:   "Vitória",
 				},
@@ -201,6 +396,7 @@ func TestExtractEvents(t *testing.T) {
 					Tournament: "Brasileirão",
 					Stadium:    "Castelão",
 					DateTime:   time.Date(year, 10, 5, 0, 0, 0, 0, loc),
+					TimeStatus: event.TimeToBeDefined,
 					HomeTeam:   "Fortaleza",
 					AwayTeam:   "Atlético",
 				},
@@ -208,6 +404,7 @@ func TestExtractEvents(t *testing.T) {
 					Tournament: "Libertadores",
 					Stadium:    "Nuevo Gasómetro",
 					DateTime:   time.Date(year, 8, 13, 0, 0, 0, 0, loc),
+					TimeStatus: event.TimeToBeDefined,
 					HomeTeam:   "San Lorenzo",
 					AwayTeam:   "Atlético",
 				},
@@ -223,7 +420,7 @@ func TestExtractEvents(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			events, err := event.ExtractEvents(f, loc)
+			events, err := event.ExtractEvents(f, loc, append(tt.opts, event.WithClock(clock.Fixed(now)))...)
 			_ = f.Close()
 
 			if !errors.Is(err, tt.wantErr) {
@@ -236,3 +433,272 @@ func TestExtractEvents(t *testing.T) {
 		})
 	}
 }
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestExtractEventsDateRange(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		startDate time.Time
+		endDate   time.Time
+		wantDates []time.Time
+		wantErr   error
+	}{
+		{
+			name:      "it should infer the year of each date from a range within a single year",
+			startDate: time.Date(2024, 1, 1, 0, 0, 0, 0, loc),
+			endDate:   time.Date(2024, 6, 30, 23, 59, 59, 0, loc),
+			wantDates: []time.Time{
+				time.Date(2024, 1, 19, 16, 0, 0, 0, loc),
+				time.Date(2024, 4, 30, 21, 30, 0, 0, loc),
+				time.Date(2024, 5, 22, 19, 0, 0, 0, loc),
+			},
+		},
+		{
+			name:      "it should infer the year of each date from a range spanning new year",
+			startDate: time.Date(2023, 12, 15, 10, 0, 0, 0, loc),
+			endDate:   time.Date(2024, 5, 31, 23, 59, 59, 0, loc),
+			wantDates: []time.Time{
+				time.Date(2024, 1, 19, 16, 0, 0, 0, loc),
+				time.Date(2024, 4, 30, 21, 30, 0, 0, loc),
+				time.Date(2024, 5, 22, 19, 0, 0, 0, loc),
+			},
+		},
+		{
+			name:      "it should include events on the first day of the range, whatever the time",
+			startDate: time.Date(2024, 1, 19, 18, 0, 0, 0, loc),
+			endDate:   time.Date(2024, 5, 22, 0, 0, 0, 0, loc),
+			wantDates: []time.Time{
+				time.Date(2024, 1, 19, 16, 0, 0, 0, loc),
+				time.Date(2024, 4, 30, 21, 30, 0, 0, loc),
+				time.Date(2024, 5, 22, 19, 0, 0, 0, loc),
+			},
+		},
+		{
+			name:      "it should return date out of range error when no year fits",
+			startDate: time.Date(2024, 2, 1, 0, 0, 0, 0, loc),
+			endDate:   time.Date(2024, 5, 31, 23, 59, 59, 0, loc),
+			wantErr:   event.ErrDateOutOfRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(".testdata/agenda.html")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			events, err := event.ExtractEvents(f, loc, event.WithDateRange(tt.startDate, tt.endDate))
+			_ = f.Close()
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err: expected %v, got %v", tt.wantErr, err)
+			}
+
+			var dates []time.Time
+			for _, ev := range events {
+				dates = append(dates, ev.DateTime)
+			}
+			if diff := cmp.Diff(tt.wantDates, dates); diff != "" {
+				t.Errorf("ExtractEvents() dates mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExtractEventsFinishedDateRange(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(".testdata/agenda_finished_scores.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	startDate := time.Date(2024, 5, 1, 0, 0, 0, 0, loc)
+	events, err := event.ExtractEvents(f, loc, event.WithFinished(),
+		event.WithDateRange(startDate, startDate.AddDate(0, 3, 0)))
+	if err != nil {
+		t.Fatalf("ExtractEvents(): unexpected error: %v", err)
+	}
+
+	var dates []time.Time
+	for _, ev := range events {
+		dates = append(dates, ev.DateTime)
+	}
+	want := []time.Time{
+		// Finished matches, dated before the window start
+		time.Date(2024, 4, 4, 0, 0, 0, 0, loc),
+		time.Date(2024, 4, 10, 0, 0, 0, 0, loc),
+		time.Date(2024, 4, 23, 0, 0, 0, 0, loc),
+		time.Date(2024, 5, 7, 19, 0, 0, 0, loc),
+		time.Date(2024, 5, 14, 19, 0, 0, 0, loc),
+		time.Date(2024, 5, 28, 19, 0, 0, 0, loc),
+	}
+	if diff := cmp.Diff(want, dates); diff != "" {
+		t.Errorf("ExtractEvents() dates mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExtractEventsLenient(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, loc)
+	opts := []event.ExtractOption{event.WithClock(clock.Fixed(now))}
+
+	t.Run("it should fail on the first malformed match by default", func(t *testing.T) {
+		f, err := os.Open(".testdata/agenda_malformed.html")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		events, err := event.ExtractEvents(f, loc, opts...)
+		var perr *event.ParseError
+		if !errors.As(err, &perr) {
+			t.Fatalf("err: expected *event.ParseError, got %v", err)
+		}
+		if !errors.Is(err, event.ErrUnexpectedInput) {
+			t.Errorf("err: expected %v, got %v", event.ErrUnexpectedInput, err)
+		}
+		if events != nil {
+			t.Errorf("unexpected events: %v", events)
+		}
+	})
+
+	t.Run("it should skip malformed matches in lenient mode", func(t *testing.T) {
+		f, err := os.Open(".testdata/agenda_malformed.html")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		events, err := event.ExtractEvents(f, loc, append(opts, event.WithLenient())...)
+
+		want := []event.Event{
+			{
+				Tournament:    "Campeonato Mineiro",
+				Stadium:       "Mário Helênio",
+				DateTime:      time.Date(2024, 1, 19, 16, 0, 0, 0, loc),
+				LocalDateTime: time.Date(2024, 1, 19, 16, 0, 0, 0, loc),
+				HomeTeam:      "Aymorés",
+				AwayTeam:      "Atlético",
+			},
+		}
+		if diff := cmp.Diff(want, events); diff != "" {
+			t.Errorf("ExtractEvents() mismatch (-want +got):\n%s", diff)
+		}
+
+		var errs event.ParseErrors
+		if !errors.As(err, &errs) {
+			t.Fatalf("err: expected event.ParseErrors, got %v", err)
+		}
+		got := make([][3]string, len(errs))
+		for i, perr := range errs {
+			got[i] = [3]string{fmt.Sprint(perr.Index), perr.Field, perr.Raw}
+		}
+		wantErrs := [][3]string{
+			{"0", "datetime", "22/05 às meio-dia"},
+			{"1", "teams", "Atlético"},
+		}
+		if diff := cmp.Diff(wantErrs, got); diff != "" {
+			t.Errorf("ParseErrors mismatch (-want +got):\n%s", diff)
+		}
+		if !errors.Is(err, event.ErrUnexpectedInput) {
+			t.Errorf("err: expected %v, got %v", event.ErrUnexpectedInput, err)
+		}
+	})
+}
+
+func TestExtractEventsOverlappingStatusLabels(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sel := event.DefaultSelectors()
+	sel.StatusLabels["a"] = "live"
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, loc)
+
+	// Map order varies between runs, so repeat to catch the shorter prefix
+	// winning by chance
+	for range 20 {
+		f, err := os.Open(".testdata/agenda_status.html")
+		if err != nil {
+			t.Fatal(err)
+		}
+		events, err := event.ExtractEvents(f, loc, event.WithSelectors(sel), event.WithClock(clock.Fixed(now)))
+		_ = f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := events[1].Status; got != event.StatusPostponed {
+			t.Fatalf("expected the longest label prefix to win, got %s", got)
+		}
+	}
+}
+
+func TestExtractEventsLocalDateTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "stadiums.json")
+	override := `[{"name": "Arena MRV", "city": "Belo Horizonte", "country": "BR", "timezone": "America/Manaus"}]`
+	if err := os.WriteFile(path, []byte(override), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	stadiums, err := registry.LoadStadiums(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(".testdata/agenda.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, loc)
+	events, err := event.ExtractEvents(f, loc, event.WithClock(clock.Fixed(now)), event.WithStadiums(stadiums))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		stadium  string
+		wantZone string
+		wantTime string
+	}{
+		{stadium: "Mário Helênio", wantZone: "America/Sao_Paulo", wantTime: "16:00"},
+		{stadium: "Arena MRV", wantZone: "America/Manaus", wantTime: "20:30"},
+		{stadium: "Arena Pernambuco", wantZone: "America/Recife", wantTime: "19:00"},
+	}
+
+	if len(events) != len(tests) {
+		t.Fatalf("unexpected length of events, want %d, got %d", len(tests), len(events))
+	}
+	for i, tt := range tests {
+		got := events[i].LocalDateTime
+		if events[i].Stadium != tt.stadium || got.Location().String() != tt.wantZone || got.Format("15:04") != tt.wantTime {
+			t.Errorf("%s: expected %s in %s, got %s in %s",
+				events[i].Stadium, tt.wantTime, tt.wantZone, got.Format("15:04"), got.Location())
+		}
+	}
+}
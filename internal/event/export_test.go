@@ -0,0 +1,5 @@
+package event
+
+// Unregister exposes unregister to tests, so that they leave the registry as
+// they found it.
+var Unregister = unregister
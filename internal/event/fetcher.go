@@ -0,0 +1,260 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/romanodesouza/galendario/internal/clock"
+)
+
+const (
+	DefaultTimeout     = 30 * time.Second
+	DefaultMaxRetries  = 3
+	DefaultBaseBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff  = 10 * time.Second
+
+	maxBodySize = 10 << 20
+)
+
+// Fetcher retrieves and parses the atletico.com.br agenda page. It implements
+// Source and is registered as the default one.
+type Fetcher struct {
+	// Client performs the HTTP requests. http.DefaultClient is used when nil.
+	Client *http.Client
+	// URL of the agenda page.
+	URL string
+	// Timeout bounds each attempt, including reading the response body.
+	Timeout time.Duration
+	// MaxRetries is the number of extra attempts made after a network error
+	// or a 5xx response.
+	MaxRetries int
+	// BaseBackoff and MaxBackoff bound the exponential backoff between
+	// attempts. Each wait is jittered.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Cache, when set, stores every fetched page and is used to send
+	// conditional requests.
+	Cache *Cache
+	// Offline serves pages from Cache only, without touching the network.
+	Offline bool
+	// SnapshotDir, when set, receives a copy of every response the agenda
+	// could not be read from, for layout drift analysis.
+	SnapshotDir string
+	// ExtractOptions are passed on to ExtractEvents.
+	ExtractOptions []ExtractOption
+	// Clock stamps cached pages and is passed on to ExtractEvents. The
+	// system clock is used when nil.
+	Clock clock.Clock
+}
+
+// NewFetcher returns a Fetcher using client and the default settings.
+func NewFetcher(client *http.Client) *Fetcher {
+	return &Fetcher{
+		Client:      client,
+		URL:         baseURL,
+		Timeout:     DefaultTimeout,
+		MaxRetries:  DefaultMaxRetries,
+		BaseBackoff: DefaultBaseBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+	}
+}
+
+func (f *Fetcher) Fetch(ctx context.Context, startDate, endDate time.Time) ([]Event, error) {
+	opts := append([]ExtractOption{WithDateRange(startDate, endDate), WithClock(f.Clock)}, f.ExtractOptions...)
+
+	page, err := f.fetchPage(ctx, formValues(startDate, endDate))
+	if err != nil {
+		var serr *StatusError
+		if errors.As(err, &serr) {
+			return nil, f.drift(serr.Page, opts, err)
+		}
+		return nil, err
+	}
+
+	// In lenient mode, events come along with ParseErrors: bad rows alone are
+	// no drift, a page none could be read from is
+	events, err := ExtractEvents(bytes.NewReader(page.Body), startDate.Location(), opts...)
+	var errs ParseErrors
+	if errors.Is(err, ErrUnexpectedInput) && (!errors.As(err, &errs) || len(events) == 0) {
+		return events, f.drift(page, opts, err)
+	}
+	return events, err
+}
+
+// drift reports a response the agenda could not be read from, saving it to
+// SnapshotDir when set.
+func (f *Fetcher) drift(page *CachedPage, opts []ExtractOption, err error) error {
+	report := newDriftReport(page, newExtractOptions(opts).selectors, err)
+	if f.SnapshotDir != "" {
+		if serr := saveSnapshot(f.SnapshotDir, page, report); serr != nil {
+			err = errors.Join(err, serr)
+		}
+	}
+	return &DriftError{Report: report, Err: err}
+}
+
+func formValues(startDate, endDate time.Time) url.Values {
+	return url.Values{
+		"data-inicio": []string{startDate.Format("02/01/2006")},
+		"data-final":  []string{endDate.Format("02/01/2006")},
+	}
+}
+
+func (f *Fetcher) fetchPage(ctx context.Context, form url.Values) (*CachedPage, error) {
+	var cached *CachedPage
+	if f.Cache != nil {
+		page, err := f.Cache.Get(form)
+		switch {
+		case err == nil:
+			cached = page
+		case !errors.Is(err, ErrCacheMiss):
+			return nil, err
+		}
+	}
+
+	if f.Offline {
+		if cached == nil {
+			return nil, fmt.Errorf("offline mode: %s: %w", form.Encode(), ErrCacheMiss)
+		}
+		return cached, nil
+	}
+
+	page, err := f.fetchWithRetries(ctx, form, cached)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Cache != nil {
+		if err := f.Cache.Put(form, page); err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+func (f *Fetcher) fetchWithRetries(ctx context.Context, form url.Values, cached *CachedPage) (*CachedPage, error) {
+	var lastErr error
+	for attempt := 0; attempt <= f.MaxRetries; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(f.backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, fmt.Errorf("gave up on %s after %d attempt(s): %w", f.URL, attempt, errors.Join(ctx.Err(), lastErr))
+			case <-timer.C:
+			}
+		}
+
+		page, retry, err := f.do(ctx, form, cached)
+		if err == nil {
+			return page, nil
+		}
+		if !retry || ctx.Err() != nil {
+			return nil, err
+		}
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("gave up on %s after %d attempt(s): %w", f.URL, f.MaxRetries+1, lastErr)
+}
+
+// do performs a single attempt and reports whether a failure is worth retrying.
+// When cached is given, the request is made conditional on its validators and
+// a 304 response yields the cached body.
+func (f *Fetcher) do(ctx context.Context, form url.Values, cached *CachedPage) (*CachedPage, bool, error) {
+	if f.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", f.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, false, fmt.Errorf("could not build POST request object for %s: %w", f.URL, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/115.0")
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := f.client().Do(req)
+	if err != nil {
+		return nil, true, fmt.Errorf("could not make POST request to %s: %w", f.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		page := *cached
+		page.FetchedAt = clock.OrSystem(f.Clock).Now()
+		return &page, false, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, true, fmt.Errorf("could not read response body from %s: %w", f.URL, err)
+	}
+
+	page := &CachedPage{
+		URL:          f.URL,
+		Form:         form.Encode(),
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header,
+		Body:         body,
+		FetchedAt:    clock.OrSystem(f.Clock).Now(),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, resp.StatusCode >= 500, &StatusError{Page: page}
+	}
+
+	return page, false, nil
+}
+
+// StatusError is returned when the agenda page answers with an unexpected
+// status code.
+type StatusError struct {
+	Page *CachedPage
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code from %s: %d", e.Page.URL, e.Page.StatusCode)
+}
+
+func (f *Fetcher) client() *http.Client {
+	if f.Client != nil {
+		return f.Client
+	}
+	return http.DefaultClient
+}
+
+// backoff returns the wait before the given attempt: exponential growth capped
+// at MaxBackoff, with a random jitter of up to half of it.
+func (f *Fetcher) backoff(attempt int) time.Duration {
+	d := f.BaseBackoff << (attempt - 1)
+	if d <= 0 || (f.MaxBackoff > 0 && d > f.MaxBackoff) {
+		d = f.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
@@ -0,0 +1,118 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/romanodesouza/galendario/internal/event"
+)
+
+func TestFetcher(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		statuses     []int
+		maxRetries   int
+		wantAttempts int32
+		wantEvents   int
+		wantErr      bool
+	}{
+		{
+			name:         "it should fetch the agenda on the first attempt",
+			statuses:     []int{http.StatusOK},
+			maxRetries:   3,
+			wantAttempts: 1,
+			wantEvents:   3,
+		},
+		{
+			name:         "it should retry on 5xx responses",
+			statuses:     []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries:   3,
+			wantAttempts: 3,
+			wantEvents:   3,
+		},
+		{
+			name:         "it should not retry on 4xx responses",
+			statuses:     []int{http.StatusNotFound, http.StatusOK},
+			maxRetries:   3,
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "it should give up after the max retries",
+			statuses:     []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK},
+			maxRetries:   1,
+			wantAttempts: 2,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				status := tt.statuses[n-1]
+				if status != http.StatusOK {
+					w.WriteHeader(status)
+					return
+				}
+				if got := r.PostFormValue("data-inicio"); got != "01/01/2024" {
+					t.Errorf("unexpected data-inicio: %q", got)
+				}
+				http.ServeFile(w, r, ".testdata/agenda.html")
+			}))
+			defer srv.Close()
+
+			f := event.NewFetcher(srv.Client())
+			f.URL = srv.URL
+			f.MaxRetries = tt.maxRetries
+			f.BaseBackoff = time.Millisecond
+			f.MaxBackoff = 2 * time.Millisecond
+
+			start := time.Date(2024, 1, 1, 0, 0, 0, 0, loc)
+			events, err := f.Fetch(context.Background(), start, start.AddDate(0, 6, 0))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err: expected error %v, got %v", tt.wantErr, err)
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("unexpected attempts, want %d, got %d", tt.wantAttempts, got)
+			}
+			if len(events) != tt.wantEvents {
+				t.Errorf("unexpected length of events, want %d, got %d", tt.wantEvents, len(events))
+			}
+		})
+	}
+}
+
+func TestFetcherTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	f := event.NewFetcher(srv.Client())
+	f.URL = srv.URL
+	f.Timeout = 10 * time.Millisecond
+	f.MaxRetries = 0
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	_, err := f.Fetch(context.Background(), now, now)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err: expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
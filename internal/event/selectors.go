@@ -0,0 +1,146 @@
+package event
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/antchfx/xpath"
+)
+
+const (
+	// SelectorsVersion is the selector profile version this package reads.
+	SelectorsVersion = 1
+)
+
+var (
+	ErrInvalidSelectors = errors.New("invalid selector profile")
+
+	//go:embed selectors.json
+	defaultSelectors []byte
+)
+
+// Selectors is a profile of the XPath expressions used to read the agenda
+// page, so that a layout change can be handled by configuration. Expressions
+// other than Title and the match lists are evaluated against each match node.
+type Selectors struct {
+	Version int `json:"version"`
+	// Title selects the page title, which must start with TitlePrefix (case
+	// insensitive) for the page to be taken as the agenda.
+	Title       string `json:"title"`
+	TitlePrefix string `json:"title_prefix"`
+	// Container selects the part of the page holding the matches, whose
+	// class names make up the page fingerprint. ExpectedClasses are the ones
+	// the other expressions rely on.
+	Container       string   `json:"container"`
+	ExpectedClasses []string `json:"expected_classes"`
+	// Matches selects every match node, UpcomingMatches the ones not yet
+	// finished.
+	Matches         string `json:"matches"`
+	UpcomingMatches string `json:"upcoming_matches"`
+	DateTime        string `json:"date_time"`
+	Stadium         string `json:"stadium"`
+	Tournament      string `json:"tournament"`
+	// Teams selects the home and away team nodes, in this order, whose name is
+	// held in the TeamNameAttr attribute.
+	Teams        string `json:"teams"`
+	TeamNameAttr string `json:"team_name_attr"`
+	// Score selects the home and away score nodes, in this order.
+	Score string `json:"score"`
+	// MatchLink selects the link to the match detail page.
+	MatchLink string `json:"match_link"`
+	// TicketLink selects the link to the ticket shop, TicketSale the text
+	// announcing when sales open, such as "Vendas a partir de 24/04 às 10:00".
+	TicketLink string `json:"ticket_link"`
+	TicketSale string `json:"ticket_sale"`
+	// DetailRound, DetailPhase and DetailReferee are evaluated against the
+	// match detail page. The value is the text after the first colon, or the
+	// whole text when there is none.
+	DetailRound   string `json:"detail_round"`
+	DetailPhase   string `json:"detail_phase"`
+	DetailReferee string `json:"detail_referee"`
+	// Status selects the label telling the state of a match, such as
+	// "Adiado". StatusLabels maps label prefixes (case insensitive) and
+	// StatusClasses match node classes to a status name, as returned by
+	// Status.String. Labels take precedence.
+	Status        string            `json:"status"`
+	StatusLabels  map[string]string `json:"status_labels"`
+	StatusClasses map[string]string `json:"status_classes"`
+	// Broadcasts selects one node per broadcaster, named by its text or, for
+	// logos, its alt or title attribute.
+	Broadcasts string `json:"broadcasts"`
+}
+
+// DefaultSelectors returns the embedded profile matching the current layout.
+func DefaultSelectors() *Selectors {
+	var s Selectors
+	if err := json.Unmarshal(defaultSelectors, &s); err != nil {
+		panic(fmt.Sprintf("event: invalid embedded selector profile: %v", err))
+	}
+	return &s
+}
+
+// LoadSelectors reads a profile from path. Fields missing from the file keep
+// their default value.
+func LoadSelectors(path string) (*Selectors, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("LoadSelectors(): could not read %s: %w", path, err)
+	}
+
+	s := DefaultSelectors()
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("LoadSelectors(): could not decode %s: %w", path, err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("LoadSelectors(): %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Validate checks the profile version and that every expression compiles.
+func (s *Selectors) Validate() error {
+	if s.Version != SelectorsVersion {
+		return fmt.Errorf("unsupported version %d, want %d: %w", s.Version, SelectorsVersion, ErrInvalidSelectors)
+	}
+	if s.TitlePrefix == "" || s.TeamNameAttr == "" {
+		return fmt.Errorf("title_prefix and team_name_attr must be set: %w", ErrInvalidSelectors)
+	}
+
+	exprs := []struct {
+		name, expr string
+	}{
+		{"title", s.Title},
+		{"container", s.Container},
+		{"matches", s.Matches},
+		{"upcoming_matches", s.UpcomingMatches},
+		{"date_time", s.DateTime},
+		{"stadium", s.Stadium},
+		{"tournament", s.Tournament},
+		{"teams", s.Teams},
+		{"score", s.Score},
+		{"status", s.Status},
+		{"broadcasts", s.Broadcasts},
+		{"match_link", s.MatchLink},
+		{"ticket_link", s.TicketLink},
+		{"ticket_sale", s.TicketSale},
+		{"detail_round", s.DetailRound},
+		{"detail_phase", s.DetailPhase},
+		{"detail_referee", s.DetailReferee},
+	}
+	for _, e := range exprs {
+		if _, err := xpath.Compile(e.expr); err != nil {
+			return fmt.Errorf("%s: %q: %v: %w", e.name, e.expr, err, ErrInvalidSelectors)
+		}
+	}
+	for _, names := range []map[string]string{s.StatusLabels, s.StatusClasses} {
+		for key, name := range names {
+			if _, err := ParseStatus(name); err != nil {
+				return fmt.Errorf("status %q: %v: %w", key, err, ErrInvalidSelectors)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package event_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/romanodesouza/galendario/internal/event"
+)
+
+func TestLoadSelectors(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		want    func(t *testing.T, s *event.Selectors)
+		wantErr error
+	}{
+		{
+			name:    "it should keep defaults for fields missing from the override file",
+			profile: `{"version": 1, "title_prefix": "Agenda"}`,
+			want: func(t *testing.T, s *event.Selectors) {
+				if s.TitlePrefix != "Agenda" {
+					t.Errorf("unexpected title prefix: %q", s.TitlePrefix)
+				}
+				if s.Matches != event.DefaultSelectors().Matches {
+					t.Errorf("unexpected matches expression: %q", s.Matches)
+				}
+			},
+		},
+		{
+			name:    "it should reject unsupported versions",
+			profile: `{"version": 2}`,
+			wantErr: event.ErrInvalidSelectors,
+		},
+		{
+			name:    "it should merge status labels into the default ones",
+			profile: `{"version": 1, "status_labels": {"suspens": "postponed"}}`,
+			want: func(t *testing.T, s *event.Selectors) {
+				if s.StatusLabels["suspens"] != "postponed" || s.StatusLabels["adiad"] != "postponed" {
+					t.Errorf("unexpected status labels: %v", s.StatusLabels)
+				}
+			},
+		},
+		{
+			name:    "it should reject unknown status names",
+			profile: `{"version": 1, "status_classes": {"partida-suspensa": "suspended"}}`,
+			wantErr: event.ErrInvalidSelectors,
+		},
+		{
+			name:    "it should reject invalid expressions",
+			profile: `{"version": 1, "teams": "//div[@class="}`,
+			wantErr: event.ErrInvalidSelectors,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "selectors.json")
+			if err := os.WriteFile(path, []byte(tt.profile), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			s, err := event.LoadSelectors(path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err: expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.want != nil {
+				tt.want(t, s)
+			}
+		})
+	}
+}
+
+func TestDefaultSelectorsAreValid(t *testing.T) {
+	if err := event.DefaultSelectors().Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractEventsWithSelectors(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sel := event.DefaultSelectors()
+	sel.TitlePrefix = "Página não encontrada"
+	sel.UpcomingMatches = `//div[@class="partida"]`
+
+	f, err := os.Open(".testdata/404.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	events, err := event.ExtractEvents(f, loc, event.WithSelectors(sel))
+	if err != nil {
+		t.Fatalf("err: expected the custom title prefix to be honored, got %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("unexpected length of events, want 0, got %d", len(events))
+	}
+}
@@ -0,0 +1,88 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultSource = "atletico"
+)
+
+var (
+	ErrUnknownSource = errors.New("unknown event source")
+)
+
+// Source is a provider of events within a date range. A source may return
+// partial results along with a ParseErrors error.
+type Source interface {
+	Fetch(ctx context.Context, startDate, endDate time.Time) ([]Event, error)
+}
+
+// SourceFunc adapts a plain function to the Source interface.
+type SourceFunc func(ctx context.Context, startDate, endDate time.Time) ([]Event, error)
+
+func (f SourceFunc) Fetch(ctx context.Context, startDate, endDate time.Time) ([]Event, error) {
+	return f(ctx, startDate, endDate)
+}
+
+var (
+	sourcesMu sync.RWMutex
+	sources   = make(map[string]Source)
+)
+
+// Register makes a source available by name. It panics if the name is already
+// taken or the source is nil, following the database/sql driver convention.
+func Register(name string, src Source) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
+	if src == nil {
+		panic("event: Register source is nil")
+	}
+	if _, dup := sources[name]; dup {
+		panic("event: Register called twice for source " + name)
+	}
+	sources[name] = src
+}
+
+// Lookup returns the source registered under name.
+func Lookup(name string) (Source, error) {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+
+	src, ok := sources[name]
+	if !ok {
+		return nil, fmt.Errorf("Lookup(): %q: %w", name, ErrUnknownSource)
+	}
+	return src, nil
+}
+
+// Sources returns the sorted names of all registered sources.
+func Sources() []string {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	Register(DefaultSource, NewFetcher(nil))
+}
+
+// unregister removes the source registered under name, if any.
+func unregister(name string) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
+	delete(sources, name)
+}
@@ -0,0 +1,41 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/romanodesouza/galendario/internal/event"
+)
+
+func TestRegistry(t *testing.T) {
+	want := []event.Event{{HomeTeam: "Atlético", AwayTeam: "Cruzeiro"}}
+	event.Register("test-registry", event.SourceFunc(func(_ context.Context, _, _ time.Time) ([]event.Event, error) {
+		return want, nil
+	}))
+	t.Cleanup(func() { event.Unregister("test-registry") })
+
+	src, err := event.Lookup("test-registry")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := src.Fetch(context.Background(), time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Fetch() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := event.Lookup("missing"); !errors.Is(err, event.ErrUnknownSource) {
+		t.Errorf("err: expected %v, got %v", event.ErrUnknownSource, err)
+	}
+
+	names := event.Sources()
+	if !cmp.Equal(names, []string{event.DefaultSource, "test-registry"}) {
+		t.Errorf("unexpected sources: %v", names)
+	}
+}
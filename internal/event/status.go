@@ -0,0 +1,90 @@
+package event
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html"
+)
+
+// Status tells where a match stands in its lifecycle.
+type Status int
+
+const (
+	StatusScheduled Status = iota
+	StatusPostponed
+	StatusCancelled
+	StatusLive
+	StatusFinished
+)
+
+var statusNames = map[Status]string{
+	StatusScheduled: "scheduled",
+	StatusPostponed: "postponed",
+	StatusCancelled: "cancelled",
+	StatusLive:      "live",
+	StatusFinished:  "finished",
+}
+
+func (s Status) String() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// ParseStatus returns the status named name, as returned by String.
+func ParseStatus(name string) (Status, error) {
+	for s, n := range statusNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("ParseStatus(): unknown status %q", name)
+}
+
+// parseStatus tells the status of a match from its label, then from the
+// classes of its node. Matches with a score are taken as finished otherwise.
+func (o extractOptions) parseStatus(node *html.Node, scored bool) Status {
+	sel := o.selectors
+
+	if label, err := htmlquery.Query(node, sel.Status); err == nil && label != nil {
+		text := strings.ToLower(strings.TrimSpace(htmlquery.InnerText(label)))
+		for _, prefix := range labelPrefixes(sel.StatusLabels) {
+			if text != "" && strings.HasPrefix(text, strings.ToLower(prefix)) {
+				s, _ := ParseStatus(sel.StatusLabels[prefix])
+				return s
+			}
+		}
+	}
+
+	for _, class := range strings.Fields(htmlquery.SelectAttr(node, "class")) {
+		if name, ok := sel.StatusClasses[class]; ok {
+			s, _ := ParseStatus(name)
+			return s
+		}
+	}
+
+	if scored {
+		return StatusFinished
+	}
+	return StatusScheduled
+}
+
+// labelPrefixes returns the prefixes of labels longest first, then sorted, so
+// that the most specific one wins when they overlap.
+func labelPrefixes(labels map[string]string) []string {
+	prefixes := make([]string, 0, len(labels))
+	for prefix := range labels {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		if len(prefixes[i]) != len(prefixes[j]) {
+			return len(prefixes[i]) > len(prefixes[j])
+		}
+		return prefixes[i] < prefixes[j]
+	})
+	return prefixes
+}
@@ -0,0 +1,68 @@
+package event
+
+import (
+	"fmt"
+
+	"github.com/romanodesouza/galendario/internal/registry"
+)
+
+// DefaultClub is the canonical name of the club whose agenda is read.
+const DefaultClub = "Atlético"
+
+// Venue tells where a match is played from the club's point of view.
+type Venue int
+
+const (
+	// VenueUnknown means the club plays neither side of the match.
+	VenueUnknown Venue = iota
+	VenueHome
+	VenueAway
+	// VenueNeutral means the match is played at a ground neither side calls
+	// home, as in cup finals.
+	VenueNeutral
+)
+
+func (v Venue) String() string {
+	switch v {
+	case VenueUnknown:
+		return "unknown"
+	case VenueHome:
+		return "home"
+	case VenueAway:
+		return "away"
+	case VenueNeutral:
+		return "neutral"
+	}
+	return fmt.Sprintf("Venue(%d)", int(v))
+}
+
+// Venue classifies the match for club. Stadiums known to be some club's home
+// ground decide it; otherwise the club plays at home when listed as the home
+// team.
+func (e Event) Venue(club string, stadiums *registry.Stadiums) Venue {
+	var opponent string
+	switch club {
+	case e.HomeTeam:
+		opponent = e.AwayTeam
+	case e.AwayTeam:
+		opponent = e.HomeTeam
+	default:
+		return VenueUnknown
+	}
+
+	if stadium, ok := stadiums.Lookup(e.Stadium); ok && len(stadium.Teams) > 0 {
+		switch {
+		case stadium.HomeOf(club):
+			return VenueHome
+		case stadium.HomeOf(opponent):
+			return VenueAway
+		default:
+			return VenueNeutral
+		}
+	}
+
+	if club == e.HomeTeam {
+		return VenueHome
+	}
+	return VenueAway
+}
@@ -0,0 +1,62 @@
+package event_test
+
+import (
+	"testing"
+
+	"github.com/romanodesouza/galendario/internal/event"
+	"github.com/romanodesouza/galendario/internal/registry"
+)
+
+func TestEventVenue(t *testing.T) {
+	stadiums := registry.DefaultStadiums()
+
+	tests := []struct {
+		name  string
+		event event.Event
+		want  event.Venue
+	}{
+		{
+			name:  "it should classify matches at the club's ground as home",
+			event: event.Event{Stadium: "Arena MRV", HomeTeam: "Atlético", AwayTeam: "Caracas"},
+			want:  event.VenueHome,
+		},
+		{
+			name:  "it should classify matches at the club's ground as home even when listed as visitor",
+			event: event.Event{Stadium: "Arena MRV", HomeTeam: "Cruzeiro", AwayTeam: "Atlético"},
+			want:  event.VenueHome,
+		},
+		{
+			name:  "it should classify matches at the opponent's ground as away",
+			event: event.Event{Stadium: "Gigante de Arroyito", HomeTeam: "Rosario Central", AwayTeam: "Atlético"},
+			want:  event.VenueAway,
+		},
+		{
+			name:  "it should classify matches at a third club's ground as neutral",
+			event: event.Event{Stadium: "Mineirão", HomeTeam: "Atlético", AwayTeam: "Flamengo"},
+			want:  event.VenueNeutral,
+		},
+		{
+			name:  "it should fall back to the home team at grounds of unknown clubs",
+			event: event.Event{Stadium: "Mário Helênio", HomeTeam: "Aymorés", AwayTeam: "Atlético"},
+			want:  event.VenueAway,
+		},
+		{
+			name:  "it should fall back to the home team at unknown stadiums",
+			event: event.Event{Stadium: "Ipatingão", HomeTeam: "Atlético", AwayTeam: "Ipatinga"},
+			want:  event.VenueHome,
+		},
+		{
+			name:  "it should not classify matches the club does not play",
+			event: event.Event{Stadium: "Mineirão", HomeTeam: "Cruzeiro", AwayTeam: "Flamengo"},
+			want:  event.VenueUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.Venue(event.DefaultClub, stadiums); got != tt.want {
+				t.Errorf("Venue(): expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Package gist publishes the calendar to a GitHub Gist.
+package gist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/romanodesouza/galendario/internal/ical"
+)
+
+const (
+	DefaultBaseURL  = "https://api.github.com"
+	DefaultFilename = "galendario.ics"
+	DefaultTimeout  = 30 * time.Second
+
+	apiVersion  = "2022-11-28"
+	maxBodySize = 10 << 20
+)
+
+var (
+	ErrFileNotFound = errors.New("file not found in gist")
+)
+
+// Client talks to the GitHub Gists API.
+type Client struct {
+	// HTTPClient performs the requests. http.DefaultClient is used when nil.
+	HTTPClient *http.Client
+	// BaseURL of the API, without trailing slash.
+	BaseURL string
+	// Token authenticates the requests to BaseURL; it needs the gist scope.
+	Token string
+	// Timeout bounds each request, including reading the response body.
+	Timeout time.Duration
+}
+
+// NewClient returns a Client for the public API using token.
+func NewClient(token string) *Client {
+	return &Client{
+		BaseURL: DefaultBaseURL,
+		Token:   token,
+		Timeout: DefaultTimeout,
+	}
+}
+
+type gistFile struct {
+	Content   string `json:"content"`
+	Truncated bool   `json:"truncated,omitempty"`
+	RawURL    string `json:"raw_url,omitempty"`
+}
+
+type gist struct {
+	Description string               `json:"description,omitempty"`
+	Files       map[string]*gistFile `json:"files"`
+}
+
+// File returns the content of a file of the gist, or ErrFileNotFound.
+func (c *Client) File(ctx context.Context, id, filename string) ([]byte, error) {
+	var g gist
+	if err := c.do(ctx, http.MethodGet, c.BaseURL+"/gists/"+id, nil, &g); err != nil {
+		return nil, fmt.Errorf("Client.File(): %w", err)
+	}
+
+	f, ok := g.Files[filename]
+	if !ok || f == nil {
+		return nil, fmt.Errorf("Client.File(): %s: %w", filename, ErrFileNotFound)
+	}
+	if !f.Truncated {
+		return []byte(f.Content), nil
+	}
+
+	// Large files only come in full from their raw URL
+	var raw bytes.Buffer
+	if err := c.do(ctx, http.MethodGet, f.RawURL, nil, &raw); err != nil {
+		return nil, fmt.Errorf("Client.File(): %w", err)
+	}
+	return raw.Bytes(), nil
+}
+
+// UpdateFile replaces the content of a file of the gist.
+func (c *Client) UpdateFile(ctx context.Context, id, filename string, content []byte) error {
+	body := gist{
+		Description: "Update",
+		Files:       map[string]*gistFile{filename: {Content: string(content)}},
+	}
+	if err := c.do(ctx, http.MethodPatch, c.BaseURL+"/gists/"+id, body, nil); err != nil {
+		return fmt.Errorf("Client.UpdateFile(): %w", err)
+	}
+	return nil
+}
+
+// Publish updates a calendar file of the gist, unless its events do not
+// differ from the published ones. It reports whether an update happened. A
+// calendar that cannot be read is never published.
+func (c *Client) Publish(ctx context.Context, id, filename string, calendar []byte) (bool, error) {
+	if err := ical.Validate(bytes.NewReader(calendar)); err != nil {
+		return false, fmt.Errorf("Client.Publish(): %w", err)
+	}
+
+	current, err := c.File(ctx, id, filename)
+	switch {
+	case errors.Is(err, ErrFileNotFound):
+	case err != nil:
+		return false, err
+	default:
+		// The new calendar was read above, so only an unreadable published
+		// one fails here, and it is replaced
+		diffs, err := ical.Diff(bytes.NewReader(current), bytes.NewReader(calendar))
+		if err == nil && len(diffs) == 0 {
+			return false, nil
+		}
+	}
+
+	if err := c.UpdateFile(ctx, id, filename, calendar); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// do sends a request with in encoded as JSON, and decodes the response into
+// out: as JSON, or raw when it is a *bytes.Buffer.
+func (c *Client) do(ctx context.Context, method, url string, in, out any) error {
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("could not encode request body: %w", err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("could not build %s request object for %s: %w", method, url, err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+	// Raw URLs live on another host, and need no token
+	if c.Token != "" && strings.HasPrefix(url, strings.TrimSuffix(c.BaseURL, "/")+"/") {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client().Do(req)
+	if err != nil {
+		return fmt.Errorf("could not make %s request to %s: %w", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return fmt.Errorf("could not read response body from %s: %w", url, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &StatusError{Method: method, URL: url, StatusCode: resp.StatusCode, Message: apiMessage(b)}
+	}
+
+	switch out := out.(type) {
+	case nil:
+	case *bytes.Buffer:
+		out.Write(b)
+	default:
+		if err := json.Unmarshal(b, out); err != nil {
+			return fmt.Errorf("could not decode response body from %s: %w", url, err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) client() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// StatusError is returned when the API answers with an unexpected status
+// code.
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	// Message is the error message of the API, when given.
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("unexpected status code from %s %s: %d", e.Method, e.URL, e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func apiMessage(body []byte) string {
+	var resp struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &resp) != nil {
+		return ""
+	}
+	return strings.TrimSpace(resp.Message)
+}
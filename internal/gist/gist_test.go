@@ -0,0 +1,187 @@
+package gist_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/romanodesouza/galendario/internal/gist"
+	"github.com/romanodesouza/galendario/internal/ical"
+)
+
+const calendar = "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:test\r\n" +
+	"BEGIN:VEVENT\r\nUID:a\r\nDTSTAMP:%s\r\nDTSTART:20240511T210000Z\r\n" +
+	"SUMMARY:Atlético x Grêmio\r\nDESCRIPTION:%s\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+
+func newCalendar(stamp, description string) string {
+	return strings.NewReplacer("DTSTAMP:%s", "DTSTAMP:"+stamp, "DESCRIPTION:%s", "DESCRIPTION:"+description).
+		Replace(calendar)
+}
+
+// server is a stand-in for the Gists API serving a single gist, with raw
+// files on a host of their own.
+type server struct {
+	*httptest.Server
+	raw       *httptest.Server
+	files     map[string]string
+	truncated bool
+	patches   int
+}
+
+func newServer(t *testing.T, token string, files map[string]string) *server {
+	s := &server{files: files}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /gists/abc", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "Bad credentials"}`))
+			return
+		}
+		files := make(map[string]any)
+		for name, content := range s.files {
+			if s.truncated {
+				files[name] = map[string]any{"content": content[:10], "truncated": true, "raw_url": s.raw.URL + "/" + name}
+				continue
+			}
+			files[name] = map[string]any{"content": content}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"files": files})
+	})
+	mux.HandleFunc("PATCH /gists/abc", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Files map[string]struct {
+				Content string `json:"content"`
+			} `json:"files"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("could not decode patch: %v", err)
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		for name, f := range body.Files {
+			s.files[name] = f.Content
+		}
+		s.patches++
+		_, _ = w.Write([]byte(`{}`))
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	s.raw = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("unexpected credentials sent to %s", r.URL)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(s.files[strings.TrimPrefix(r.URL.Path, "/")]))
+	}))
+	t.Cleanup(s.raw.Close)
+	return s
+}
+
+func TestPublish(t *testing.T) {
+	published := newCalendar("20240501T120000Z", `Brasileirão`)
+
+	tests := []struct {
+		name        string
+		files       map[string]string
+		truncated   bool
+		calendar    string
+		wantUpdated bool
+	}{
+		{
+			name:     "it should not update a calendar whose events did not change",
+			files:    map[string]string{"galendario.ics": published},
+			calendar: newCalendar("20240502T120000Z", `Brasileirão`),
+		},
+		{
+			name:        "it should update a calendar whose events changed",
+			files:       map[string]string{"galendario.ics": published},
+			calendar:    newCalendar("20240502T120000Z", `Brasileirão\nTransmissão: "Premiere" \\ Globo`),
+			wantUpdated: true,
+		},
+		{
+			name:        "it should read truncated files from their raw URL",
+			files:       map[string]string{"galendario.ics": published},
+			truncated:   true,
+			calendar:    newCalendar("20240502T120000Z", `Copa do Brasil`),
+			wantUpdated: true,
+		},
+		{
+			name:        "it should add a missing calendar file",
+			files:       map[string]string{"README.md": "# Galendário"},
+			calendar:    published,
+			wantUpdated: true,
+		},
+		{
+			name:        "it should replace an unreadable calendar file",
+			files:       map[string]string{"galendario.ics": "null"},
+			calendar:    published,
+			wantUpdated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newServer(t, "secret", tt.files)
+			srv.truncated = tt.truncated
+
+			c := gist.NewClient("secret")
+			c.BaseURL = srv.URL
+			updated, err := c.Publish(context.Background(), "abc", gist.DefaultFilename, []byte(tt.calendar))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated != tt.wantUpdated {
+				t.Errorf("updated: expected %t, got %t", tt.wantUpdated, updated)
+			}
+			if updated && srv.files[gist.DefaultFilename] != tt.calendar {
+				t.Errorf("unexpected published content:\n%q", srv.files[gist.DefaultFilename])
+			}
+			if !updated && srv.patches > 0 {
+				t.Errorf("unexpected patches: %d", srv.patches)
+			}
+		})
+	}
+}
+
+func TestPublishBadCredentials(t *testing.T) {
+	srv := newServer(t, "secret", map[string]string{})
+
+	c := gist.NewClient("wrong")
+	c.BaseURL = srv.URL
+	_, err := c.Publish(context.Background(), "abc", gist.DefaultFilename, []byte(newCalendar("20240501T120000Z", "")))
+
+	var serr *gist.StatusError
+	if !errors.As(err, &serr) {
+		t.Fatalf("err: expected *gist.StatusError, got %v", err)
+	}
+	if serr.StatusCode != http.StatusUnauthorized || serr.Message != "Bad credentials" {
+		t.Errorf("unexpected status error: %v", serr)
+	}
+}
+
+func TestPublishInvalidCalendar(t *testing.T) {
+	published := newCalendar("20240501T120000Z", `Brasileirão`)
+
+	for _, calendar := range []string{"", "garbage", "<html><body>502 Bad Gateway</body></html>", "BEGIN:VCALENDAR\r\n"} {
+		t.Run(fmt.Sprintf("it should not publish %q", calendar), func(t *testing.T) {
+			srv := newServer(t, "secret", map[string]string{gist.DefaultFilename: published})
+
+			c := gist.NewClient("secret")
+			c.BaseURL = srv.URL
+			updated, err := c.Publish(context.Background(), "abc", gist.DefaultFilename, []byte(calendar))
+			if !errors.Is(err, ical.ErrInvalidCalendar) {
+				t.Errorf("err: expected %v, got %v", ical.ErrInvalidCalendar, err)
+			}
+			if updated || srv.patches > 0 || srv.files[gist.DefaultFilename] != published {
+				t.Errorf("expected the published calendar to be kept")
+			}
+		})
+	}
+}
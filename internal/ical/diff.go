@@ -0,0 +1,194 @@
+package ical
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+)
+
+var (
+	ErrInvalidCalendar = errors.New("invalid calendar")
+)
+
+// DiffKind tells how an event differs between two calendars.
+type DiffKind string
+
+const (
+	DiffAdded   DiffKind = "added"
+	DiffRemoved DiffKind = "removed"
+	DiffChanged DiffKind = "changed"
+)
+
+// EventDiff is an event that differs between two calendars, matched by UID.
+type EventDiff struct {
+	UID     string   `json:"uid"`
+	Kind    DiffKind `json:"kind"`
+	Summary string   `json:"summary"`
+	// Start is the event start in the new calendar, or the old one for
+	// removed events.
+	Start string `json:"start"`
+	// Fields lists what changed, for changed events.
+	Fields []FieldDiff `json:"fields,omitempty"`
+}
+
+// FieldDiff is a property that differs between two versions of an event.
+type FieldDiff struct {
+	Name string `json:"name"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// diffFields are the properties compared between two versions of an event.
+// Stamps such as DTSTAMP or SEQUENCE change on every run and are left out.
+var diffFields = []struct {
+	name  string
+	prop  ics.ComponentProperty
+	value func(*ics.VEvent, ics.ComponentProperty) string
+}{
+	{"start", ics.ComponentPropertyDtStart, timeValue},
+	{"end", ics.ComponentPropertyDtEnd, timeValue},
+	{"summary", ics.ComponentPropertySummary, textValue},
+	{"location", ics.ComponentPropertyLocation, textValue},
+	{"geo", ics.ComponentPropertyGeo, textValue},
+	{"categories", ics.ComponentPropertyCategories, listValue},
+	{"status", ics.ComponentPropertyStatus, textValue},
+	{"description", ics.ComponentPropertyDescription, textValue},
+}
+
+// Diff parses two calendars and returns the events added, removed or changed
+// from the old one to the new one: new events in order, then removed ones.
+func Diff(before, after io.Reader) ([]EventDiff, error) {
+	oldCal, err := parseCalendar(before)
+	if err != nil {
+		return nil, fmt.Errorf("Diff(): could not parse old calendar: %w", err)
+	}
+	newCal, err := parseCalendar(after)
+	if err != nil {
+		return nil, fmt.Errorf("Diff(): could not parse new calendar: %w", err)
+	}
+
+	oldEvents := make(map[string]*ics.VEvent)
+	for _, ev := range oldCal.Events() {
+		oldEvents[ev.Id()] = ev
+	}
+
+	var diffs []EventDiff
+	seen := make(map[string]bool)
+	for _, ev := range newCal.Events() {
+		seen[ev.Id()] = true
+		prev, ok := oldEvents[ev.Id()]
+		if !ok {
+			diffs = append(diffs, newEventDiff(ev, DiffAdded))
+			continue
+		}
+
+		var fields []FieldDiff
+		for _, f := range diffFields {
+			if o, n := f.value(prev, f.prop), f.value(ev, f.prop); o != n {
+				fields = append(fields, FieldDiff{Name: f.name, Old: o, New: n})
+			}
+		}
+		if len(fields) > 0 {
+			d := newEventDiff(ev, DiffChanged)
+			d.Fields = fields
+			diffs = append(diffs, d)
+		}
+	}
+	for _, ev := range oldCal.Events() {
+		if !seen[ev.Id()] {
+			diffs = append(diffs, newEventDiff(ev, DiffRemoved))
+		}
+	}
+	return diffs, nil
+}
+
+// Validate tells whether r holds a whole calendar.
+func Validate(r io.Reader) error {
+	if _, err := parseCalendar(r); err != nil {
+		return fmt.Errorf("Validate(): %w", err)
+	}
+	return nil
+}
+
+// parseCalendar parses a calendar, rejecting the empty or truncated input
+// ics.ParseCalendar lets through as an empty calendar.
+func parseCalendar(r io.Reader) (*ics.Calendar, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasSuffix(bytes.TrimSpace(b), []byte("END:VCALENDAR")) {
+		return nil, fmt.Errorf("missing END:VCALENDAR: %w", ErrInvalidCalendar)
+	}
+	cal, err := ics.ParseCalendar(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidCalendar)
+	}
+	return cal, nil
+}
+
+func newEventDiff(ev *ics.VEvent, kind DiffKind) EventDiff {
+	return EventDiff{
+		UID:     ev.Id(),
+		Kind:    kind,
+		Summary: textValue(ev, ics.ComponentPropertySummary),
+		Start:   timeValue(ev, ics.ComponentPropertyDtStart),
+	}
+}
+
+// timeValue returns a date, as in "2024-05-11", or a time in UTC, so that
+// the same instant compares equal whatever its time zone.
+func timeValue(ev *ics.VEvent, prop ics.ComponentProperty) string {
+	p := ev.GetProperty(prop)
+	if p == nil {
+		return ""
+	}
+	if t, err := time.Parse("20060102", p.Value); err == nil {
+		return t.Format(time.DateOnly)
+	}
+
+	var t time.Time
+	var err error
+	switch prop {
+	case ics.ComponentPropertyDtStart:
+		t, err = ev.GetStartAt()
+	case ics.ComponentPropertyDtEnd:
+		t, err = ev.GetEndAt()
+	}
+	if err != nil || t.IsZero() {
+		return p.Value
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func textValue(ev *ics.VEvent, prop ics.ComponentProperty) string {
+	p := ev.GetProperty(prop)
+	if p == nil {
+		return ""
+	}
+	return unescapeText(p.Value)
+}
+
+// listValue joins every value of a property that may repeat, such as
+// CATEGORIES.
+func listValue(ev *ics.VEvent, prop ics.ComponentProperty) string {
+	var values []string
+	for _, p := range ev.Properties {
+		if p.IANAToken == string(prop) {
+			values = append(values, unescapeText(p.Value))
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+var textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, "\n", `\N`, "\n")
+
+// unescapeText reverts the TEXT value escaping of RFC 5545.
+func unescapeText(s string) string {
+	return textUnescaper.Replace(s)
+}
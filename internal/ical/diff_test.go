@@ -0,0 +1,158 @@
+package ical_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/romanodesouza/galendario/internal/clock"
+	"github.com/romanodesouza/galendario/internal/event"
+	"github.com/romanodesouza/galendario/internal/ical"
+	"github.com/romanodesouza/galendario/internal/registry"
+)
+
+func TestDiff(t *testing.T) {
+	gremio := event.Event{
+		Tournament: "Brasileirão",
+		Stadium:    "Arena MRV",
+		DateTime:   time.Date(2024, 5, 11, 21, 0, 0, 0, time.UTC),
+		HomeTeam:   "Atlético",
+		AwayTeam:   "Grêmio",
+	}
+	bahia := event.Event{
+		Tournament: "Brasileirão",
+		Stadium:    "Arena Fonte Nova",
+		DateTime:   time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC),
+		TimeStatus: event.TimeToBeDefined,
+		HomeTeam:   "Bahia",
+		AwayTeam:   "Atlético",
+	}
+	cuiaba := event.Event{
+		Tournament: "Brasileirão",
+		Stadium:    "Arena Pantanal",
+		DateTime:   time.Date(2024, 5, 26, 18, 30, 0, 0, time.UTC),
+		HomeTeam:   "Cuiabá",
+		AwayTeam:   "Atlético",
+	}
+	postponed := gremio
+	postponed.Status = event.StatusPostponed
+	postponed.Broadcasts = []string{"Premiere"}
+
+	serialize := func(t *testing.T, now time.Time, events ...event.Event) string {
+		t.Helper()
+		cal := ical.NewCalendar("Test", ical.WithClock(clock.Fixed(now)))
+		cal.AddEvents(events)
+		var buf bytes.Buffer
+		if err := cal.SerializeTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	day1 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want []string
+	}{
+		{
+			name: "it should ignore stamps that change on every run",
+			old:  serialize(t, day1, gremio, bahia),
+			new:  serialize(t, day2, gremio, bahia),
+		},
+		{
+			name: "it should report added, removed and changed events",
+			old:  serialize(t, day1, gremio, bahia),
+			new:  serialize(t, day2, postponed, cuiaba),
+			want: []string{
+				"changed Atlético x Grêmio 2024-05-11T21:00:00Z",
+				"  status: CONFIRMED -> TENTATIVE",
+				"  description: Brasileirão -> Brasileirão\nTransmissão: Premiere",
+				"added Cuiabá x Atlético 2024-05-26T18:30:00Z",
+				"removed Bahia x Atlético 2024-05-19",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs, err := ical.Diff(strings.NewReader(tt.old), strings.NewReader(tt.new))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, d := range diffs {
+				got = append(got, strings.Join([]string{string(d.Kind), d.Summary, d.Start}, " "))
+				for _, f := range d.Fields {
+					got = append(got, "  "+f.Name+": "+f.Old+" -> "+f.New)
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Diff() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffVenue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stadiums.json")
+	override := `[{"name": "Arena MRV", "teams": ["Atlético"], "city": "Belo Horizonte", "country": "BR",
+		"address": "Av. Presidente Juscelino Kubitschek, 3300 - Califórnia", "latitude": -19.91, "longitude": -44.03}]`
+	if err := os.WriteFile(path, []byte(override), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	stadiums, err := registry.LoadStadiums(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gremio := event.Event{
+		Tournament: "Brasileirão",
+		Stadium:    "Arena MRV",
+		DateTime:   time.Date(2024, 5, 11, 21, 0, 0, 0, time.UTC),
+		HomeTeam:   "Atlético",
+		AwayTeam:   "Grêmio",
+	}
+	serialize := func(t *testing.T, opts ...ical.Option) string {
+		t.Helper()
+		cal := ical.NewCalendar("Test", opts...)
+		cal.AddEvents([]event.Event{gremio})
+		var buf bytes.Buffer
+		if err := cal.SerializeTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	old := serialize(t)
+	updated := serialize(t, ical.WithStadiums(stadiums), ical.WithClub("Grêmio"))
+	diffs, err := ical.Diff(strings.NewReader(old), strings.NewReader(updated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 1 {
+		t.Fatalf("unexpected length of diffs, want 1, got %d", len(diffs))
+	}
+
+	want := []ical.FieldDiff{
+		{Name: "geo", Old: "-19.9096;-44.0257", New: "-19.91;-44.03"},
+		{Name: "categories", Old: "Brasileirão, Casa", New: "Brasileirão, Fora"},
+	}
+	if diff := cmp.Diff(want, diffs[0].Fields); diff != "" {
+		t.Errorf("Diff() fields mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiffInvalidCalendar(t *testing.T) {
+	if _, err := ical.Diff(strings.NewReader("not a calendar"), strings.NewReader("")); err == nil {
+		t.Error("expected an error for an invalid calendar")
+	}
+}
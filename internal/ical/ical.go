@@ -4,40 +4,200 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	ics "github.com/arran4/golang-ical"
+	"github.com/romanodesouza/galendario/internal/clock"
 	"github.com/romanodesouza/galendario/internal/event"
+	"github.com/romanodesouza/galendario/internal/registry"
+	"github.com/romanodesouza/galendario/internal/store"
 )
 
 type Calendar struct {
-	cal *ics.Calendar
+	cal         *ics.Calendar
+	ticketSales bool
+	tournaments *registry.Tournaments
+	stadiums    *registry.Stadiums
+	localTime   bool
+	club        string
+	uids        *UIDs
+	history     *store.Store
+	clock       clock.Clock
 }
 
-func NewCalendar(name string) *Calendar {
+// Option customizes a Calendar.
+type Option func(*Calendar)
+
+// WithTicketSales adds a "Venda de ingressos" event, related to its match, for
+// every match whose ticket sale start is known.
+func WithTicketSales() Option {
+	return func(c *Calendar) {
+		c.ticketSales = true
+	}
+}
+
+// WithLocalTime adds the kickoff time in the venue's time zone to the
+// description of matches played in another time zone, as in "19h em Rosario /
+// 19h em Brasília".
+func WithLocalTime() Option {
+	return func(c *Calendar) {
+		c.localTime = true
+	}
+}
+
+// WithClub classifies matches as home, away or neutral from the given club's
+// point of view instead of the default one.
+func WithClub(name string) Option {
+	return func(c *Calendar) {
+		c.club = name
+	}
+}
+
+// WithUIDs reuses the UIDs matches were given before, so that they survive
+// rescheduling. New ones are recorded in u, to be saved by the caller.
+func WithUIDs(u *UIDs) Option {
+	return func(c *Calendar) {
+		c.uids = u
+	}
+}
+
+// WithHistory sets the UID, SEQUENCE, CREATED and LAST-MODIFIED from the match
+// history in s, which must have recorded the events beforehand.
+func WithHistory(s *store.Store) Option {
+	return func(c *Calendar) {
+		c.history = s
+	}
+}
+
+// WithClock sets the clock DTSTAMP is taken from. The system clock is used
+// otherwise.
+func WithClock(clk clock.Clock) Option {
+	return func(c *Calendar) {
+		c.clock = clk
+	}
+}
+
+// WithTournaments looks tournaments up in the given registry instead of the
+// default one.
+func WithTournaments(t *registry.Tournaments) Option {
+	return func(c *Calendar) {
+		c.tournaments = t
+	}
+}
+
+// WithStadiums looks stadiums up in the given registry instead of the default
+// one.
+func WithStadiums(s *registry.Stadiums) Option {
+	return func(c *Calendar) {
+		c.stadiums = s
+	}
+}
+
+func NewCalendar(name string, opts ...Option) *Calendar {
 	cal := ics.NewCalendar()
 	cal.SetName(name)
 
-	return &Calendar{
-		cal: cal,
+	c := &Calendar{
+		cal:         cal,
+		tournaments: registry.DefaultTournaments(),
+		stadiums:    registry.DefaultStadiums(),
+		club:        event.DefaultClub,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.clock = clock.OrSystem(c.clock)
+	return c
 }
 
 func (c *Calendar) AddEvents(events []event.Event) {
-	for _, event := range events {
-		event.DateTime = AdjustedDateTime(event.DateTime)
-		ev := c.cal.AddEvent(icalUID(event))
+	for _, e := range events {
+		uid := c.uid(e)
+		ev := c.cal.AddEvent(uid)
 		// Event has time confirmed
-		if event.DateTime.Hour() != 0 {
-			ev.SetStartAt(event.DateTime)
-			ev.SetEndAt(event.DateTime.Add(2 * time.Hour))
+		if e.TimeStatus == event.TimeConfirmed {
+			ev.SetStartAt(e.DateTime)
+			ev.SetEndAt(e.DateTime.Add(2 * time.Hour))
 		} else { // Event has no time confirmed - flag it as whole-day event
-			ev.SetAllDayStartAt(event.DateTime)
+			ev.SetAllDayStartAt(e.DateTime)
+		}
+		ev.SetSummary(summary(e))
+		ev.SetStatus(status(e))
+		c.setLocation(ev, e.Stadium)
+		desc := description(e)
+		if c.localTime {
+			if line := c.localKickoff(e); line != "" {
+				desc += "\n" + line
+			}
+		}
+		ev.SetDescription(desc)
+		if tournament, ok := c.tournaments.Lookup(e.Tournament); ok {
+			ev.AddCategory(tournament.Name)
+			if tournament.Color != "" {
+				ev.SetColor(tournament.Color)
+			}
+		}
+		if category, ok := venueCategories[e.Venue(c.club, c.stadiums)]; ok {
+			ev.AddCategory(category)
+		}
+		ev.SetDtStampTime(c.clock.Now().UTC())
+		c.setRevision(ev, e)
+
+		if c.ticketSales && e.TicketSale != nil && !e.TicketSale.Start.IsZero() {
+			c.addTicketSale(e, uid)
 		}
-		ev.SetSummary(fmt.Sprintf("%s x %s", event.HomeTeam, event.AwayTeam))
-		ev.SetLocation(event.Stadium)
-		ev.SetDescription(event.Tournament)
-		ev.SetDtStampTime(event.DateTime.In(time.UTC))
+	}
+}
+
+func (c *Calendar) addTicketSale(e event.Event, matchUID string) {
+	sale := e.TicketSale
+	ev := c.cal.AddEvent(ticketSaleUID(matchUID))
+	if sale.TimeStatus == event.TimeConfirmed {
+		ev.SetStartAt(sale.Start)
+		ev.SetEndAt(sale.Start.Add(time.Hour))
+	} else {
+		ev.SetAllDayStartAt(sale.Start)
+	}
+	ev.SetSummary(fmt.Sprintf("Venda de ingressos: %s x %s", e.HomeTeam, e.AwayTeam))
+	c.setLocation(ev, e.Stadium)
+	desc := e.Tournament
+	if sale.URL != "" {
+		ev.SetURL(sale.URL)
+		desc += "\n" + sale.URL
+	}
+	ev.SetDescription(desc)
+	ev.SetProperty(ics.ComponentProperty(ics.PropertyRelatedTo), matchUID)
+	ev.SetDtStampTime(c.clock.Now().UTC())
+}
+
+// setRevision sets the SEQUENCE, CREATED and LAST-MODIFIED of the match from
+// its history.
+func (c *Calendar) setRevision(ev *ics.VEvent, e event.Event) {
+	if c.history == nil {
+		return
+	}
+	match, ok := c.history.Lookup(e)
+	if !ok {
+		return
+	}
+
+	ev.SetSequence(match.Sequence())
+	ev.SetCreatedTime(match.Created().UTC())
+	ev.SetLastModifiedAt(match.LastModified().UTC())
+}
+
+// setLocation sets the stadium as the event location, along with its address
+// and coordinates when it is a known one.
+func (c *Calendar) setLocation(ev *ics.VEvent, name string) {
+	stadium, ok := c.stadiums.Lookup(name)
+	if !ok {
+		ev.SetLocation(name)
+		return
+	}
+	ev.SetLocation(stadium.FullAddress())
+	if stadium.HasCoordinates() {
+		ev.SetGeo(stadium.Latitude, stadium.Longitude)
 	}
 }
 
@@ -49,8 +209,98 @@ func (c *Calendar) SerializeTo(w io.Writer) error {
 	return c.cal.SerializeTo(w)
 }
 
+// venueCategories names the CATEGORIES value of each venue classification.
+var venueCategories = map[event.Venue]string{
+	event.VenueHome:    "Casa",
+	event.VenueAway:    "Fora",
+	event.VenueNeutral: "Neutro",
+}
+
+// status maps the match status to the iCalendar one: cancelled matches are
+// CANCELLED, and postponed ones or those without a kickoff time TENTATIVE.
+func status(ev event.Event) ics.ObjectStatus {
+	switch {
+	case ev.Status == event.StatusCancelled:
+		return ics.ObjectStatusCancelled
+	case ev.Status == event.StatusPostponed, ev.TimeStatus == event.TimeToBeDefined:
+		return ics.ObjectStatusTentative
+	}
+	return ics.ObjectStatusConfirmed
+}
+
+// summary returns "Home x Away", including the scoreline for finished matches.
+func summary(ev event.Event) string {
+	if ev.HomeScore == nil || ev.AwayScore == nil {
+		return fmt.Sprintf("%s x %s", ev.HomeTeam, ev.AwayTeam)
+	}
+	if ev.HomePenalties != nil && ev.AwayPenalties != nil {
+		return fmt.Sprintf("%s %d (%d) x (%d) %d %s",
+			ev.HomeTeam, *ev.HomeScore, *ev.HomePenalties, *ev.AwayPenalties, *ev.AwayScore, ev.AwayTeam)
+	}
+	return fmt.Sprintf("%s %d x %d %s", ev.HomeTeam, *ev.HomeScore, *ev.AwayScore, ev.AwayTeam)
+}
+
+// description returns the tournament, followed by where to watch the match.
+func description(ev event.Event) string {
+	if len(ev.Broadcasts) == 0 {
+		return ev.Tournament
+	}
+	return fmt.Sprintf("%s\n%s%s", ev.Tournament, broadcastsPrefix, strings.Join(ev.Broadcasts, ", "))
+}
+
+// localKickoff returns the kickoff time at the venue alongside the Brasília
+// one, or "" when the venue is at the same UTC offset at kickoff.
+func (c *Calendar) localKickoff(ev event.Event) string {
+	if ev.LocalDateTime.IsZero() {
+		return ""
+	}
+	_, localOffset := ev.LocalDateTime.Zone()
+	_, offset := ev.DateTime.Zone()
+	if localOffset == offset {
+		return ""
+	}
+	city := ev.Stadium
+	if stadium, ok := c.stadiums.Lookup(ev.Stadium); ok && stadium.City != "" {
+		city = stadium.City
+	}
+	return fmt.Sprintf("%s em %s / %s em Brasília", hour(ev.LocalDateTime), city, hour(ev.DateTime))
+}
+
+// hour formats t as in "19h" or "21h30".
+func hour(t time.Time) string {
+	if t.Minute() == 0 {
+		return fmt.Sprintf("%dh", t.Hour())
+	}
+	return fmt.Sprintf("%dh%02d", t.Hour(), t.Minute())
+}
+
+// uid returns the UID of the match: the date-based UID of its first version
+// when history is set, so that it follows the match as SEQUENCE does and
+// matches the one published before, or taken from the UIDs.
+func (c *Calendar) uid(ev event.Event) string {
+	if c.history != nil {
+		if match, ok := c.history.Lookup(ev); ok {
+			return icalUID(match.Versions[0].Event)
+		}
+	}
+	if c.uids != nil {
+		return c.uids.resolve(ev)
+	}
+	return icalUID(ev)
+}
+
 func icalUID(ev event.Event) string {
-	seed := fmt.Sprintf("%d-%d-%d:%s:%s:%s",
+	return hashUID(uidSeed(ev))
+}
+
+// ticketSaleUID derives the ticket sale UID from its match UID, under its own
+// namespace, so that both follow the match when it is rescheduled.
+func ticketSaleUID(matchUID string) string {
+	return hashUID("ingressos:" + matchUID)
+}
+
+func uidSeed(ev event.Event) string {
+	return fmt.Sprintf("%d-%d-%d:%s:%s:%s",
 		ev.DateTime.Year(),
 		ev.DateTime.Month(),
 		ev.DateTime.Day(),
@@ -58,19 +308,10 @@ func icalUID(ev event.Event) string {
 		ev.HomeTeam,
 		ev.AwayTeam,
 	)
+}
+
+func hashUID(seed string) string {
 	h := sha256.New()
 	h.Write([]byte(seed))
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
-
-func AdjustedDateTime(dateTime time.Time) time.Time {
-	now := time.Now().UTC().In(dateTime.Location())
-
-	// year rollover
-	if dateTime.Month() < now.Month() {
-		return time.Date(dateTime.Year()+1, dateTime.Month(), dateTime.Day(),
-			dateTime.Hour(), dateTime.Minute(), dateTime.Second(), dateTime.Nanosecond(), dateTime.Location())
-	}
-
-	return dateTime
-}
@@ -1,13 +1,16 @@
 package ical_test
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
 	ics "github.com/arran4/golang-ical"
 	"github.com/google/go-cmp/cmp"
+	"github.com/romanodesouza/galendario/internal/clock"
 	"github.com/romanodesouza/galendario/internal/event"
 	"github.com/romanodesouza/galendario/internal/ical"
+	"github.com/romanodesouza/galendario/internal/store"
 )
 
 func TestAddEventsToIcal(t *testing.T) {
@@ -34,10 +37,10 @@ func TestAddEventsToIcal(t *testing.T) {
 			},
 			want: func() []*ics.VEvent {
 				event := ics.NewEvent("test")
-				event.SetStartAt(ical.AdjustedDateTime(time.Date(2024, 5, 7, 19, 0, 0, 0, loc)))
-				event.SetEndAt(ical.AdjustedDateTime(time.Date(2024, 5, 7, 19, 0, 0, 0, loc).Add(2 * time.Hour)))
+				event.SetStartAt(time.Date(2024, 5, 7, 19, 0, 0, 0, loc))
+				event.SetEndAt(time.Date(2024, 5, 7, 19, 0, 0, 0, loc).Add(2 * time.Hour))
 				event.SetSummary("Rosario Central x Atlético")
-				event.SetLocation("Gigante de Arroyito")
+				event.SetLocation("Gigante de Arroyito, Rosario, AR")
 				event.SetDescription("Copa Libertadores")
 				return []*ics.VEvent{event}
 			}(),
@@ -62,17 +65,17 @@ func TestAddEventsToIcal(t *testing.T) {
 			},
 			want: func() []*ics.VEvent {
 				event1 := ics.NewEvent("test")
-				event1.SetStartAt(ical.AdjustedDateTime(time.Date(2024, 5, 14, 19, 0, 0, 0, loc)))
-				event1.SetEndAt(ical.AdjustedDateTime(time.Date(2024, 5, 14, 19, 0, 0, 0, loc).Add(2 * time.Hour)))
+				event1.SetStartAt(time.Date(2024, 5, 14, 19, 0, 0, 0, loc))
+				event1.SetEndAt(time.Date(2024, 5, 14, 19, 0, 0, 0, loc).Add(2 * time.Hour))
 				event1.SetSummary("Peñarol x Atlético")
-				event1.SetLocation("Campeón del Siglo")
+				event1.SetLocation("Campeón del Siglo, Montevideo, UY")
 				event1.SetDescription("Copa Libertadores")
 
 				event2 := ics.NewEvent("test")
-				event2.SetStartAt(ical.AdjustedDateTime(time.Date(2024, 5, 28, 19, 0, 0, 0, loc)))
-				event2.SetEndAt(ical.AdjustedDateTime(time.Date(2024, 5, 28, 19, 0, 0, 0, loc).Add(2 * time.Hour)))
+				event2.SetStartAt(time.Date(2024, 5, 28, 19, 0, 0, 0, loc))
+				event2.SetEndAt(time.Date(2024, 5, 28, 19, 0, 0, 0, loc).Add(2 * time.Hour))
 				event2.SetSummary("Atlético x Caracas")
-				event2.SetLocation("Arena MRV")
+				event2.SetLocation("Arena MRV, Av. Presidente Juscelino Kubitschek, 3300 - Califórnia, Belo Horizonte, BR")
 				event2.SetDescription("Copa Libertadores")
 
 				return []*ics.VEvent{event1, event2}
@@ -85,6 +88,7 @@ func TestAddEventsToIcal(t *testing.T) {
 					Tournament: "Campeonato Brasileiro",
 					Stadium:    "Castelão",
 					DateTime:   time.Date(2024, 10, 5, 0, 0, 0, 0, loc),
+					TimeStatus: event.TimeToBeDefined,
 					HomeTeam:   "Fortaleza",
 					AwayTeam:   "Atlético",
 				},
@@ -98,16 +102,101 @@ func TestAddEventsToIcal(t *testing.T) {
 			},
 			want: func() []*ics.VEvent {
 				event1 := ics.NewEvent("test")
-				event1.SetAllDayStartAt(ical.AdjustedDateTime(time.Date(2024, 10, 5, 0, 0, 0, 0, loc)))
+				event1.SetAllDayStartAt(time.Date(2024, 10, 5, 0, 0, 0, 0, loc))
 				event1.SetSummary("Fortaleza x Atlético")
-				event1.SetLocation("Castelão")
+				event1.SetLocation("Castelão, Fortaleza, BR")
 				event1.SetDescription("Campeonato Brasileiro")
 
 				event2 := ics.NewEvent("test")
-				event2.SetStartAt(ical.AdjustedDateTime(time.Date(2024, 5, 28, 19, 0, 0, 0, loc)))
-				event2.SetEndAt(ical.AdjustedDateTime(time.Date(2024, 5, 28, 19, 0, 0, 0, loc).Add(2 * time.Hour)))
+				event2.SetStartAt(time.Date(2024, 5, 28, 19, 0, 0, 0, loc))
+				event2.SetEndAt(time.Date(2024, 5, 28, 19, 0, 0, 0, loc).Add(2 * time.Hour))
 				event2.SetSummary("Atlético x Caracas")
-				event2.SetLocation("Arena MRV")
+				event2.SetLocation("Arena MRV, Av. Presidente Juscelino Kubitschek, 3300 - Califórnia, Belo Horizonte, BR")
+				event2.SetDescription("Copa Libertadores")
+
+				return []*ics.VEvent{event1, event2}
+			}(),
+		},
+		{
+			name: "it should serialize confirmed midnight kickoffs as timed events",
+			events: []event.Event{
+				{
+					Tournament: "Copa Libertadores",
+					Stadium:    "Estadio Monumental",
+					DateTime:   time.Date(2024, 8, 14, 0, 30, 0, 0, loc),
+					HomeTeam:   "River Plate",
+					AwayTeam:   "Atlético",
+				},
+			},
+			want: func() []*ics.VEvent {
+				event := ics.NewEvent("test")
+				event.SetStartAt(time.Date(2024, 8, 14, 0, 30, 0, 0, loc))
+				event.SetEndAt(time.Date(2024, 8, 14, 0, 30, 0, 0, loc).Add(2 * time.Hour))
+				event.SetSummary("River Plate x Atlético")
+				event.SetLocation("Estadio Monumental, Av. Figueroa Alcorta 7597, Buenos Aires, AR")
+				event.SetDescription("Copa Libertadores")
+				return []*ics.VEvent{event}
+			}(),
+		},
+		{
+			name: "it should list broadcasters in the description",
+			events: []event.Event{
+				{
+					Tournament: "Copa do Brasil",
+					Stadium:    "Arena Pernambuco",
+					DateTime:   time.Date(2024, 5, 22, 19, 0, 0, 0, loc),
+					HomeTeam:   "Sport",
+					AwayTeam:   "Atlético",
+					Broadcasts: []string{"Globo", "Premiere"},
+				},
+			},
+			want: func() []*ics.VEvent {
+				event := ics.NewEvent("test")
+				event.SetStartAt(time.Date(2024, 5, 22, 19, 0, 0, 0, loc))
+				event.SetEndAt(time.Date(2024, 5, 22, 19, 0, 0, 0, loc).Add(2 * time.Hour))
+				event.SetSummary("Sport x Atlético")
+				event.SetLocation("Arena Pernambuco, São Lourenço da Mata, BR")
+				event.SetDescription("Copa do Brasil\nTransmissão: Globo, Premiere")
+				return []*ics.VEvent{event}
+			}(),
+		},
+		{
+			name: "it should serialize finished events with their final score",
+			events: []event.Event{
+				{
+					Tournament: "Copa Libertadores",
+					Stadium:    "Arena MRV",
+					DateTime:   time.Date(2024, 4, 10, 0, 0, 0, 0, loc),
+					TimeStatus: event.TimeToBeDefined,
+					HomeTeam:   "Atlético",
+					AwayTeam:   "Rosario Central",
+					HomeScore:  intPtr(2),
+					AwayScore:  intPtr(1),
+				},
+				{
+					Tournament:    "Copa Libertadores",
+					Stadium:       "Olímpico de la UCV",
+					DateTime:      time.Date(2024, 4, 4, 0, 0, 0, 0, loc),
+					TimeStatus:    event.TimeToBeDefined,
+					HomeTeam:      "Caracas",
+					AwayTeam:      "Atlético",
+					HomeScore:     intPtr(1),
+					AwayScore:     intPtr(1),
+					HomePenalties: intPtr(2),
+					AwayPenalties: intPtr(4),
+				},
+			},
+			want: func() []*ics.VEvent {
+				event1 := ics.NewEvent("test")
+				event1.SetAllDayStartAt(time.Date(2024, 4, 10, 0, 0, 0, 0, loc))
+				event1.SetSummary("Atlético 2 x 1 Rosario Central")
+				event1.SetLocation("Arena MRV, Av. Presidente Juscelino Kubitschek, 3300 - Califórnia, Belo Horizonte, BR")
+				event1.SetDescription("Copa Libertadores")
+
+				event2 := ics.NewEvent("test")
+				event2.SetAllDayStartAt(time.Date(2024, 4, 4, 0, 0, 0, 0, loc))
+				event2.SetSummary("Caracas 1 (2) x (4) 1 Atlético")
+				event2.SetLocation("Olímpico de la UCV, Caracas, VE")
 				event2.SetDescription("Copa Libertadores")
 
 				return []*ics.VEvent{event1, event2}
@@ -165,33 +254,338 @@ func TestAddEventsToIcal(t *testing.T) {
 	}
 }
 
-func Test_AdjustedDateTime(t *testing.T) {
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestAddTicketSales(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := []event.Event{
+		{
+			Tournament: "Copa do Brasil",
+			Stadium:    "Arena MRV",
+			DateTime:   time.Date(2024, 4, 30, 21, 30, 0, 0, loc),
+			HomeTeam:   "Atlético",
+			AwayTeam:   "Sport",
+			TicketSale: &event.TicketSale{
+				URL:   "https://ingressos.galonaveia.com.br/eventos/atletico-x-sport",
+				Start: time.Date(2024, 4, 24, 10, 0, 0, 0, loc),
+			},
+		},
+		{
+			Tournament: "Campeonato Mineiro",
+			Stadium:    "Mário Helênio",
+			DateTime:   time.Date(2024, 1, 19, 16, 0, 0, 0, loc),
+			HomeTeam:   "Aymorés",
+			AwayTeam:   "Atlético",
+			TicketSale: &event.TicketSale{URL: "https://ingressos.galonaveia.com.br/"},
+		},
+	}
+
+	t.Run("it should not add ticket sale events by default", func(t *testing.T) {
+		cal := ical.NewCalendar("Test")
+		cal.AddEvents(events)
+		if got := len(cal.ICalEvents()); got != 2 {
+			t.Fatalf("unexpected length of events, want 2, got %d", got)
+		}
+	})
+
+	t.Run("it should add ticket sale events related to their match", func(t *testing.T) {
+		cal := ical.NewCalendar("Test", ical.WithTicketSales())
+		cal.AddEvents(events)
+
+		icalEvents := cal.ICalEvents()
+		if len(icalEvents) != 3 {
+			t.Fatalf("unexpected length of events, want 3, got %d", len(icalEvents))
+		}
+
+		match, sale := icalEvents[0], icalEvents[1]
+		if match.Id() == sale.Id() {
+			t.Errorf("expected ticket sale UID to differ from the match UID")
+		}
+		if got := sale.GetProperty(ics.ComponentProperty(ics.PropertyRelatedTo)).Value; got != match.Id() {
+			t.Errorf("unexpected RELATED-TO, want %s, got %s", match.Id(), got)
+		}
+
+		startAt, _ := sale.GetStartAt()
+		if want := time.Date(2024, 4, 24, 10, 0, 0, 0, loc); !want.Equal(startAt) {
+			t.Errorf("unexpected ticket sale start time, want %v, got %v", want, startAt)
+		}
+		if diff := cmp.Diff(
+			"Venda de ingressos: Atlético x Sport",
+			sale.GetProperty(ics.ComponentPropertySummary).Value); diff != "" {
+
+			t.Errorf("ticket sale summary mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(
+			"https://ingressos.galonaveia.com.br/eventos/atletico-x-sport",
+			sale.GetProperty(ics.ComponentPropertyUrl).Value); diff != "" {
+
+			t.Errorf("ticket sale url mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(
+			match.GetProperty(ics.ComponentPropertyLocation).Value,
+			sale.GetProperty(ics.ComponentPropertyLocation).Value); diff != "" {
+
+			t.Errorf("ticket sale location mismatch (-want +got):\n%s", diff)
+		}
+		if got := sale.GetProperty(ics.ComponentPropertyGeo); got == nil || got.Value != "-19.9096;-44.0257" {
+			t.Errorf("unexpected ticket sale geo: %v", got)
+		}
+	})
+}
+
+func TestAddEventsTournamentMetadata(t *testing.T) {
+	cal := ical.NewCalendar("Test")
+	cal.AddEvents([]event.Event{
+		{Tournament: "Libertadores", DateTime: time.Date(2024, 5, 7, 19, 0, 0, 0, time.UTC)},
+		{Tournament: "Florida Cup", DateTime: time.Date(2024, 1, 10, 19, 0, 0, 0, time.UTC)},
+	})
+
+	icalEvents := cal.ICalEvents()
+	if got := icalEvents[0].GetProperty(ics.ComponentPropertyColor); got == nil || got.Value != "gold" {
+		t.Errorf("unexpected color for a known tournament: %v", got)
+	}
+	if got := icalEvents[0].GetProperty(ics.ComponentPropertyCategories); got == nil || got.Value != "Libertadores" {
+		t.Errorf("unexpected categories for a known tournament: %v", got)
+	}
+	if got := icalEvents[1].GetProperty(ics.ComponentPropertyColor); got != nil {
+		t.Errorf("unexpected color for an unknown tournament: %v", got.Value)
+	}
+}
+
+func TestAddEventsStadiumLocation(t *testing.T) {
+	cal := ical.NewCalendar("Test")
+	cal.AddEvents([]event.Event{
+		{Stadium: "Arena MRV", DateTime: time.Date(2024, 5, 7, 19, 0, 0, 0, time.UTC)},
+		{Stadium: "Estádio do Café", DateTime: time.Date(2024, 1, 10, 19, 0, 0, 0, time.UTC)},
+	})
+
+	icalEvents := cal.ICalEvents()
+	if got := icalEvents[0].GetProperty(ics.ComponentPropertyGeo); got == nil || got.Value != "-19.9096;-44.0257" {
+		t.Errorf("unexpected geo for a known stadium: %v", got)
+	}
+	if got := icalEvents[1].GetProperty(ics.ComponentPropertyGeo); got != nil {
+		t.Errorf("unexpected geo for an unknown stadium: %v", got.Value)
+	}
+	if got := icalEvents[1].GetProperty(ics.ComponentPropertyLocation); got == nil || got.Value != "Estádio do Café" {
+		t.Errorf("unexpected location for an unknown stadium: %v", got)
+	}
+}
+
+func TestAddEventsLocalTime(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	caracas, err := time.LoadLocation("America/Caracas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	recife, err := time.LoadLocation("America/Recife")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kickoff := time.Date(2024, 5, 28, 21, 30, 0, 0, saoPaulo)
+	events := []event.Event{
+		{
+			Tournament:    "Libertadores",
+			Stadium:       "Olímpico de la UCV",
+			DateTime:      kickoff,
+			LocalDateTime: kickoff.In(caracas),
+		},
+		{
+			Tournament:    "Libertadores",
+			Stadium:       "Arena MRV",
+			DateTime:      kickoff,
+			LocalDateTime: kickoff,
+		},
+		{
+			Tournament:    "Brasileirão",
+			Stadium:       "Arena Pernambuco",
+			DateTime:      kickoff,
+			LocalDateTime: kickoff.In(recife),
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts []ical.Option
+		want []string
+	}{
+		{
+			name: "it should leave the local kickoff time out by default",
+			want: []string{"Libertadores", "Libertadores", "Brasileirão"},
+		},
+		{
+			name: "it should add the local kickoff time of matches at another UTC offset only",
+			opts: []ical.Option{ical.WithLocalTime()},
+			want: []string{`Libertadores\n20h30 em Caracas / 21h30 em Brasília`, "Libertadores", "Brasileirão"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := ical.NewCalendar("Test", tt.opts...)
+			cal.AddEvents(events)
+
+			var got []string
+			for _, ev := range cal.ICalEvents() {
+				got = append(got, ev.GetProperty(ics.ComponentPropertyDescription).Value)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("descriptions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAddEventsVenueCategory(t *testing.T) {
+	events := []event.Event{
+		{Stadium: "Arena MRV", HomeTeam: "Atlético", AwayTeam: "Caracas"},
+		{Stadium: "Campeón del Siglo", HomeTeam: "Peñarol", AwayTeam: "Atlético"},
+		{Stadium: "Mineirão", HomeTeam: "Atlético", AwayTeam: "Flamengo"},
+	}
+
+	tests := []struct {
+		name string
+		opts []ical.Option
+		want [][]string
+	}{
+		{
+			name: "it should classify matches from the default club's point of view",
+			want: [][]string{{"Casa"}, {"Fora"}, {"Neutro"}},
+		},
+		{
+			name: "it should classify matches from the configured club's point of view",
+			opts: []ical.Option{ical.WithClub("Peñarol")},
+			want: [][]string{nil, {"Casa"}, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := ical.NewCalendar("Test", tt.opts...)
+			cal.AddEvents(events)
+
+			var got [][]string
+			for _, ev := range cal.ICalEvents() {
+				var categories []string
+				for _, prop := range ev.Properties {
+					if prop.IANAToken == string(ics.ComponentPropertyCategories) {
+						categories = append(categories, prop.Value)
+					}
+				}
+				got = append(got, categories)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("categories mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAddEventsStatus(t *testing.T) {
+	kickoff := time.Date(2024, 5, 28, 21, 30, 0, 0, time.UTC)
+
 	tests := []struct {
-		name    string
-		utcMock time.Time
-		input   event.Event
-		want    int
+		name  string
+		event event.Event
+		want  ics.ObjectStatus
 	}{
 		{
-			name:    "it should roll over the year when event date's month is lower",
-			utcMock: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
-			input:   event.Event{DateTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
-			want:    2026,
+			name:  "it should confirm scheduled events with a kickoff time",
+			event: event.Event{DateTime: kickoff},
+			want:  ics.ObjectStatusConfirmed,
 		},
 		{
-			name:    "it should keep the year otherwise",
-			utcMock: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
-			input:   event.Event{DateTime: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)},
-			want:    2025,
+			name:  "it should confirm finished events",
+			event: event.Event{DateTime: kickoff, Status: event.StatusFinished},
+			want:  ics.ObjectStatusConfirmed,
+		},
+		{
+			name:  "it should flag events without a kickoff time as tentative",
+			event: event.Event{DateTime: kickoff, TimeStatus: event.TimeToBeDefined},
+			want:  ics.ObjectStatusTentative,
+		},
+		{
+			name:  "it should flag postponed events as tentative",
+			event: event.Event{DateTime: kickoff, Status: event.StatusPostponed},
+			want:  ics.ObjectStatusTentative,
+		},
+		{
+			name:  "it should cancel cancelled events",
+			event: event.Event{DateTime: kickoff, TimeStatus: event.TimeToBeDefined, Status: event.StatusCancelled},
+			want:  ics.ObjectStatusCancelled,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			dateTime := ical.AdjustedDateTime(tt.input.DateTime)
-			if got := dateTime.Year(); got != tt.want {
-				t.Fatalf("err: expected %v, got %v", tt.want, got)
+			cal := ical.NewCalendar("Test")
+			cal.AddEvents([]event.Event{tt.event})
+
+			got := cal.ICalEvents()[0].GetProperty(ics.ComponentPropertyStatus)
+			if got == nil || got.Value != string(tt.want) {
+				t.Errorf("unexpected status, want %s, got %v", tt.want, got)
 			}
 		})
 	}
 }
+
+func TestAddEventsRevision(t *testing.T) {
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+	day1 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	day3 := day2.AddDate(0, 0, 1)
+
+	match := event.Event{
+		Tournament: "Brasileirão",
+		Stadium:    "Arena MRV",
+		DateTime:   time.Date(2024, 5, 11, 21, 0, 0, 0, time.UTC),
+		HomeTeam:   "Atlético",
+		AwayTeam:   "Grêmio",
+	}
+	moved := match
+	moved.DateTime = match.DateTime.AddDate(0, 0, 1)
+	broadcast := moved
+	broadcast.Broadcasts = []string{"Premiere"}
+
+	s.Record([]event.Event{match}, start, end, day1)
+	// Without history, as published before the store was turned on
+	first := ical.NewCalendar("Test")
+	first.AddEvents([]event.Event{match})
+	s.Record([]event.Event{moved}, start, end, day2)
+	s.Record([]event.Event{broadcast}, start, end, day3)
+
+	now := time.Date(2024, 5, 4, 9, 30, 0, 0, time.UTC)
+	cal := ical.NewCalendar("Test", ical.WithHistory(s), ical.WithClock(clock.Fixed(now)))
+	cal.AddEvents([]event.Event{broadcast})
+
+	ev := cal.ICalEvents()[0]
+	want := map[ics.ComponentProperty]string{
+		ics.ComponentPropertyDtstamp:      "20240504T093000Z",
+		ics.ComponentPropertySequence:     "1",
+		ics.ComponentPropertyCreated:      "20240501T120000Z",
+		ics.ComponentPropertyLastModified: "20240503T120000Z",
+	}
+	for prop, value := range want {
+		if got := ev.GetProperty(prop); got == nil || got.Value != value {
+			t.Errorf("unexpected %s, want %s, got %v", prop, value, got)
+		}
+	}
+	if want := first.ICalEvents()[0].Id(); ev.Id() != want {
+		t.Errorf("expected the UID to follow the rescheduled match, want %s, got %s", want, ev.Id())
+	}
+}
@@ -0,0 +1,171 @@
+package ical
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+	"github.com/romanodesouza/galendario/internal/event"
+)
+
+// ReadEvents parses a calendar back into matches, with times in loc. It reads
+// what AddEvents writes: "Home x Away" summaries, with the score of finished
+// matches, the tournament and broadcasters in the description and the
+// stadium first in the location. Ticket sale events are skipped, as are
+// events whose summary names no teams.
+func ReadEvents(r io.Reader, loc *time.Location) ([]event.Event, error) {
+	cal, err := ics.ParseCalendar(r)
+	if err != nil {
+		return nil, fmt.Errorf("ReadEvents(): could not parse calendar: %w", err)
+	}
+
+	var events []event.Event
+	for _, ev := range cal.Events() {
+		if ev.GetProperty(ics.ComponentProperty(ics.PropertyRelatedTo)) != nil {
+			continue
+		}
+		e, ok := parseSummary(textValue(ev, ics.ComponentPropertySummary))
+		if !ok {
+			continue
+		}
+
+		if err := readDateTime(ev, loc, &e); err != nil {
+			return nil, fmt.Errorf("ReadEvents(): event %s: %w", ev.Id(), err)
+		}
+		stadium, _, _ := strings.Cut(textValue(ev, ics.ComponentPropertyLocation), ",")
+		e.Stadium = strings.TrimSpace(stadium)
+		readDescription(textValue(ev, ics.ComponentPropertyDescription), &e)
+		e.Status = readStatus(ev, e)
+
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+var (
+	scoredSummaryRegexp = regexp.MustCompile(`^(.+?) (\d+)(?: \((\d+)\))? x (?:\((\d+)\) )?(\d+) (.+)$`)
+	summaryRegexp       = regexp.MustCompile(`^(.+?) x (.+)$`)
+)
+
+// parseSummary reads the teams and score out of a summary, as written by
+// summary.
+func parseSummary(s string) (event.Event, bool) {
+	s = strings.TrimSpace(s)
+	if m := scoredSummaryRegexp.FindStringSubmatch(s); m != nil {
+		e := event.Event{HomeTeam: m[1], AwayTeam: m[6]}
+		e.HomeScore, e.AwayScore = atoiPtr(m[2]), atoiPtr(m[5])
+		if m[3] != "" && m[4] != "" {
+			e.HomePenalties, e.AwayPenalties = atoiPtr(m[3]), atoiPtr(m[4])
+		}
+		return e, true
+	}
+	if m := summaryRegexp.FindStringSubmatch(s); m != nil {
+		return event.Event{HomeTeam: strings.TrimSpace(m[1]), AwayTeam: strings.TrimSpace(m[2])}, true
+	}
+	return event.Event{}, false
+}
+
+func atoiPtr(s string) *int {
+	n, _ := strconv.Atoi(s)
+	return &n
+}
+
+// readDateTime reads the kickoff time, or only the date of all-day events.
+func readDateTime(ev *ics.VEvent, loc *time.Location, e *event.Event) error {
+	p := ev.GetProperty(ics.ComponentPropertyDtStart)
+	if p == nil {
+		return fmt.Errorf("missing DTSTART: %w", event.ErrUnexpectedInput)
+	}
+	if date, err := time.ParseInLocation("20060102", p.Value, loc); err == nil {
+		e.DateTime, e.TimeStatus = date, event.TimeToBeDefined
+		return nil
+	}
+
+	t, err := ev.GetStartAt()
+	if err != nil {
+		return fmt.Errorf("invalid DTSTART %q: %v: %w", p.Value, err, event.ErrUnexpectedInput)
+	}
+	e.DateTime, e.TimeStatus = t.In(loc), event.TimeConfirmed
+	return nil
+}
+
+const broadcastsPrefix = "Transmissão: "
+
+// readDescription reads the tournament, on the first line, and the
+// broadcasters out of a description, as written by description.
+func readDescription(s string, e *event.Event) {
+	lines := strings.Split(s, "\n")
+	e.Tournament = strings.TrimSpace(lines[0])
+	for _, line := range lines[1:] {
+		if list, ok := strings.CutPrefix(line, broadcastsPrefix); ok {
+			for _, b := range strings.Split(list, ",") {
+				if b = strings.TrimSpace(b); b != "" {
+					e.Broadcasts = append(e.Broadcasts, b)
+				}
+			}
+		}
+	}
+}
+
+// readStatus reverts status: TENTATIVE events with a kickoff time are taken
+// as postponed, and scored ones as finished.
+func readStatus(ev *ics.VEvent, e event.Event) event.Status {
+	var status string
+	if p := ev.GetProperty(ics.ComponentPropertyStatus); p != nil {
+		status = p.Value
+	}
+	switch {
+	case status == string(ics.ObjectStatusCancelled):
+		return event.StatusCancelled
+	case status == string(ics.ObjectStatusTentative) && e.TimeStatus == event.TimeConfirmed:
+		return event.StatusPostponed
+	case e.HomeScore != nil:
+		return event.StatusFinished
+	}
+	return event.StatusScheduled
+}
+
+// FileSourceName is the name FileSource is registered under, without a path:
+// callers looking it up set their own.
+const FileSourceName = "file"
+
+// FileSource reads matches from an ICS file, such as a previously published
+// calendar or a hand-made one. It implements event.Source.
+type FileSource struct {
+	Path string
+}
+
+func init() {
+	event.Register(FileSourceName, FileSource{})
+}
+
+// Fetch returns the matches of the file within the given window. Whole days are
+// compared, as for the agenda, so that all-day matches on startDate are kept.
+func (s FileSource) Fetch(_ context.Context, startDate, endDate time.Time) ([]event.Event, error) {
+	f, err := os.Open(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("FileSource.Fetch(): %w", err)
+	}
+	defer f.Close()
+
+	events, err := ReadEvents(f, startDate.Location())
+	if err != nil {
+		return nil, err
+	}
+
+	first := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
+	last := time.Date(endDate.Year(), endDate.Month(), endDate.Day()+1, 0, 0, 0, 0, endDate.Location())
+	var inRange []event.Event
+	for _, e := range events {
+		if !e.DateTime.Before(first) && e.DateTime.Before(last) {
+			inRange = append(inRange, e)
+		}
+	}
+	return inRange, nil
+}
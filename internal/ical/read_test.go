@@ -0,0 +1,178 @@
+package ical_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/romanodesouza/galendario/internal/event"
+	"github.com/romanodesouza/galendario/internal/ical"
+)
+
+func TestReadEvents(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := []event.Event{
+		{
+			Tournament:    "Libertadores",
+			Stadium:       "Olímpico de la UCV",
+			DateTime:      time.Date(2024, 4, 4, 0, 0, 0, 0, loc),
+			TimeStatus:    event.TimeToBeDefined,
+			Status:        event.StatusFinished,
+			HomeTeam:      "Caracas",
+			AwayTeam:      "Atlético",
+			HomeScore:     intPtr(1),
+			AwayScore:     intPtr(1),
+			HomePenalties: intPtr(2),
+			AwayPenalties: intPtr(4),
+		},
+		{
+			Tournament: "Copa do Brasil",
+			Stadium:    "Arena MRV",
+			DateTime:   time.Date(2024, 4, 30, 21, 30, 0, 0, loc),
+			HomeTeam:   "Atlético",
+			AwayTeam:   "Sport",
+			Broadcasts: []string{"Globo", "Premiere"},
+			TicketSale: &event.TicketSale{
+				URL:   "https://ingressos.atletico.com.br/",
+				Start: time.Date(2024, 4, 24, 10, 0, 0, 0, loc),
+			},
+		},
+		{
+			Tournament: "Brasileirão",
+			Stadium:    "Arena Pernambuco",
+			DateTime:   time.Date(2024, 5, 22, 19, 0, 0, 0, loc),
+			Status:     event.StatusPostponed,
+			HomeTeam:   "Sport",
+			AwayTeam:   "Atlético",
+		},
+		{
+			Tournament: "Brasileirão",
+			Stadium:    "Estádio do Café",
+			DateTime:   time.Date(2024, 6, 2, 0, 0, 0, 0, loc),
+			TimeStatus: event.TimeToBeDefined,
+			Status:     event.StatusCancelled,
+			HomeTeam:   "Londrina",
+			AwayTeam:   "Atlético",
+		},
+	}
+
+	cal := ical.NewCalendar("Test", ical.WithTicketSales(), ical.WithLocalTime())
+	cal.AddEvents(events)
+	var buf bytes.Buffer
+	if err := cal.SerializeTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ical.ReadEvents(&buf, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Ticket sales are not written back into the calendar's match events.
+	want := make([]event.Event, len(events))
+	copy(want, events)
+	want[1].TicketSale = nil
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadEvents() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadEventsHandMade(t *testing.T) {
+	input := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//hand made//EN",
+		"BEGIN:VEVENT",
+		"UID:amistoso-1",
+		"DTSTART;TZID=America/Sao_Paulo:20250118T190000",
+		"SUMMARY:Atlético x Tombense",
+		"LOCATION:Arena MRV",
+		"DESCRIPTION:Amistoso",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:lembrete",
+		"DTSTART:20250119T120000Z",
+		"SUMMARY:Renovar sócio torcedor",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	got, err := ical.ReadEvents(strings.NewReader(input), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []event.Event{
+		{
+			Tournament: "Amistoso",
+			Stadium:    "Arena MRV",
+			DateTime:   time.Date(2025, 1, 18, 22, 0, 0, 0, time.UTC),
+			HomeTeam:   "Atlético",
+			AwayTeam:   "Tombense",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadEvents() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	events := []event.Event{
+		{Tournament: "Brasileirão", DateTime: time.Date(2024, 5, 11, 21, 0, 0, 0, time.UTC), HomeTeam: "A", AwayTeam: "B"},
+		{Tournament: "Brasileirão", DateTime: time.Date(2024, 8, 11, 21, 0, 0, 0, time.UTC), HomeTeam: "C", AwayTeam: "D"},
+		{
+			Tournament: "Brasileirão",
+			DateTime:   time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			TimeStatus: event.TimeToBeDefined,
+			HomeTeam:   "E",
+			AwayTeam:   "F",
+		},
+	}
+	cal := ical.NewCalendar("Test")
+	cal.AddEvents(events)
+	var buf bytes.Buffer
+	if err := cal.SerializeTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "galendario.ics")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := event.Lookup(ical.FileSourceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileSrc, ok := src.(ical.FileSource)
+	if !ok {
+		t.Fatalf("unexpected registered source: %T", src)
+	}
+	fileSrc.Path = path
+
+	// The window starts at the time of the run, past midnight
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 7, 31, 12, 0, 0, 0, time.UTC)
+	got, err := fileSrc.Fetch(context.Background(), start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var teams []string
+	for _, e := range got {
+		teams = append(teams, e.HomeTeam)
+	}
+	if diff := cmp.Diff([]string{"A", "E"}, teams); diff != "" {
+		t.Errorf("expected only the events within the window (-want +got):\n%s", diff)
+	}
+}
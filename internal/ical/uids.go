@@ -0,0 +1,108 @@
+package ical
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/romanodesouza/galendario/internal/atomicfile"
+	"github.com/romanodesouza/galendario/internal/event"
+	"github.com/romanodesouza/galendario/internal/store"
+)
+
+// UIDs remembers the UID given to each match, so that a rescheduled match
+// keeps it and calendar clients update the event instead of replacing it.
+// Matches are followed as the store does, see store.Follow.
+type UIDs struct {
+	// MaxShift bounds how far from its last known date a match is looked for.
+	MaxShift time.Duration
+	// StartDate is the start of the window the events come from. Entries dated
+	// before its day were played, and are not followed.
+	StartDate time.Time
+
+	matches map[string][]*uidEntry
+	claimed map[*uidEntry]bool
+}
+
+type uidEntry struct {
+	UID      string    `json:"uid"`
+	DateTime time.Time `json:"date_time"`
+}
+
+func NewUIDs() *UIDs {
+	return &UIDs{
+		MaxShift: store.DefaultMaxShift,
+		matches:  make(map[string][]*uidEntry),
+		claimed:  make(map[*uidEntry]bool),
+	}
+}
+
+// LoadUIDs reads the UIDs saved at path. A missing file yields an empty set.
+func LoadUIDs(path string) (*UIDs, error) {
+	u := NewUIDs()
+	b, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return u, nil
+	case err != nil:
+		return nil, fmt.Errorf("LoadUIDs(): could not read file: %w", err)
+	}
+
+	if err := json.Unmarshal(b, &u.matches); err != nil {
+		return nil, fmt.Errorf("LoadUIDs(): could not decode file: %w", err)
+	}
+	if u.matches == nil {
+		u.matches = make(map[string][]*uidEntry)
+	}
+	return u, nil
+}
+
+// Save writes the UIDs to path, replacing it atomically. Entries dated more
+// than MaxShift before StartDate are dropped, as no match of the window can
+// follow them anymore.
+func (u *UIDs) Save(path string) error {
+	cutoff := u.StartDate.Add(-u.MaxShift)
+	for key, entries := range u.matches {
+		entries = slices.DeleteFunc(entries, func(entry *uidEntry) bool {
+			return entry.DateTime.Before(cutoff)
+		})
+		if len(entries) == 0 {
+			delete(u.matches, key)
+			continue
+		}
+		u.matches[key] = entries
+	}
+
+	b, err := json.MarshalIndent(u.matches, "", "  ")
+	if err != nil {
+		return fmt.Errorf("UIDs.Save(): could not encode UIDs: %w", err)
+	}
+	if err := atomicfile.Write(path, b); err != nil {
+		return fmt.Errorf("UIDs.Save(): %w", err)
+	}
+	return nil
+}
+
+// resolve returns the UID of the match, reusing the one of the closest known
+// entry not yet given to another event. New matches get the date-based UID.
+func (u *UIDs) resolve(ev event.Event) string {
+	key := ev.MatchKey()
+
+	unclaimed := slices.DeleteFunc(slices.Clone(u.matches[key]), func(entry *uidEntry) bool {
+		return u.claimed[entry]
+	})
+	best, ok := store.Follow(ev, unclaimed, func(entry *uidEntry) store.Identity {
+		return store.Identity{Key: key, DateTime: entry.DateTime}
+	}, u.StartDate, u.MaxShift)
+	if !ok {
+		best = &uidEntry{UID: icalUID(ev)}
+		u.matches[key] = append(u.matches[key], best)
+	}
+	best.DateTime = ev.DateTime
+	u.claimed[best] = true
+	return best.UID
+}
@@ -0,0 +1,132 @@
+package ical_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/romanodesouza/galendario/internal/event"
+	"github.com/romanodesouza/galendario/internal/ical"
+)
+
+func TestUIDs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "uids.json")
+
+	match := event.Event{
+		Tournament: "Brasileirão",
+		Stadium:    "Arena MRV",
+		DateTime:   time.Date(2024, 5, 11, 21, 0, 0, 0, time.UTC),
+		HomeTeam:   "Atlético",
+		AwayTeam:   "Grêmio",
+	}
+	rematch := match
+	rematch.DateTime = time.Date(2024, 6, 2, 16, 0, 0, 0, time.UTC)
+
+	// uids runs a calendar over events with the UIDs saved at path, and saves
+	// them back.
+	uids := func(t *testing.T, events []event.Event, maxShift time.Duration) []string {
+		t.Helper()
+		u, err := ical.LoadUIDs(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if maxShift > 0 {
+			u.MaxShift = maxShift
+		}
+		u.StartDate = match.DateTime
+
+		cal := ical.NewCalendar("Test", ical.WithUIDs(u))
+		cal.AddEvents(events)
+		if err := u.Save(path); err != nil {
+			t.Fatal(err)
+		}
+
+		var got []string
+		for _, ev := range cal.ICalEvents() {
+			got = append(got, ev.Id())
+		}
+		return got
+	}
+
+	first := uids(t, []event.Event{match, rematch}, 0)
+	plain := ical.NewCalendar("Test")
+	plain.AddEvents([]event.Event{match, rematch})
+	if first[0] != plain.ICalEvents()[0].Id() || first[1] != plain.ICalEvents()[1].Id() {
+		t.Errorf("expected new matches to get the date-based UIDs, got %v", first)
+	}
+
+	t.Run("it should keep the UID of a rescheduled match", func(t *testing.T) {
+		moved := match
+		moved.DateTime = match.DateTime.AddDate(0, 0, 3)
+		got := uids(t, []event.Event{moved, rematch}, 0)
+		if got[0] != first[0] || got[1] != first[1] {
+			t.Errorf("expected UIDs %v, got %v", first, got)
+		}
+	})
+
+	t.Run("it should not give one UID to both legs of a recurring pairing", func(t *testing.T) {
+		got := uids(t, []event.Event{rematch, rematch}, 0)
+		if got[0] != first[1] || got[1] == first[1] {
+			t.Errorf("expected the closest entry to be reused once, got %v", got)
+		}
+	})
+
+	t.Run("it should give a new UID to a match moved beyond the max shift", func(t *testing.T) {
+		far := match
+		far.DateTime = match.DateTime.AddDate(0, 3, 0)
+		got := uids(t, []event.Event{far}, 24*time.Hour)
+		if got[0] == first[0] || got[0] == first[1] {
+			t.Errorf("expected a new UID, got %v", got)
+		}
+	})
+
+	t.Run("it should drop entries older than the max shift before the window start", func(t *testing.T) {
+		moved := match
+		moved.DateTime = match.DateTime.AddDate(0, 0, 3)
+		kept := uids(t, []event.Event{moved}, 0)
+
+		u, err := ical.LoadUIDs(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u.StartDate = moved.DateTime.Add(u.MaxShift + time.Hour)
+		if err := u.Save(path); err != nil {
+			t.Fatal(err)
+		}
+
+		got := uids(t, []event.Event{moved}, 0)
+		if got[0] == kept[0] {
+			t.Errorf("expected a pruned entry to yield a new UID, got %v", got)
+		}
+	})
+
+	t.Run("it should not give the UID of a played match to the next leg", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "uids.json")
+		next := match
+		next.DateTime = match.DateTime.AddDate(0, 0, 30)
+
+		var got []string
+		for _, run := range []struct {
+			ev    event.Event
+			start time.Time
+		}{
+			{ev: match, start: match.DateTime.AddDate(0, 0, -1)},
+			{ev: next, start: match.DateTime.AddDate(0, 0, 7)},
+		} {
+			u, err := ical.LoadUIDs(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			u.StartDate = run.start
+			cal := ical.NewCalendar("Test", ical.WithUIDs(u))
+			cal.AddEvents([]event.Event{run.ev})
+			if err := u.Save(path); err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, cal.ICalEvents()[0].Id())
+		}
+		if got[0] == got[1] {
+			t.Errorf("expected the next leg to get a UID of its own, got %v", got)
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrInvalidEntry = errors.New("invalid registry entry")
+)
+
+// matcher recognizes the names an entry goes by: its canonical name, any of
+// its aliases or patterns (case-insensitive regular expressions). Names and
+// aliases are compared ignoring case, accents and hyphens.
+type matcher struct {
+	Name     string   `json:"name"`
+	Aliases  []string `json:"aliases,omitempty"`
+	Patterns []string `json:"patterns,omitempty"`
+
+	compiled []*regexp.Regexp
+}
+
+func (m *matcher) compile() error {
+	if m.Name == "" {
+		return fmt.Errorf("missing name: %w", ErrInvalidEntry)
+	}
+	m.compiled = m.compiled[:0]
+	for _, p := range m.Patterns {
+		re, err := regexp.Compile("(?i)" + p)
+		if err != nil {
+			return fmt.Errorf("%s: pattern %q: %v: %w", m.Name, p, err, ErrInvalidEntry)
+		}
+		m.compiled = append(m.compiled, re)
+	}
+	return nil
+}
+
+// matches reports whether input is one of the entry names. With substring
+// set, an alias found anywhere in input is enough.
+func (m *matcher) matches(input string, substring bool) bool {
+	folded := fold(input)
+	if folded == fold(m.Name) {
+		return true
+	}
+	for _, alias := range m.Aliases {
+		a := fold(alias)
+		if folded == a || (substring && strings.Contains(folded, a)) {
+			return true
+		}
+	}
+	for _, re := range m.compiled {
+		if re.MatchString(input) {
+			return true
+		}
+	}
+	return false
+}
+
+var accents = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// fold lowercases s, strips its accents and turns hyphens into spaces.
+func fold(s string) string {
+	if folded, _, err := transform.String(accents, s); err == nil {
+		s = folded
+	}
+	s = strings.ReplaceAll(strings.ToLower(s), "-", " ")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// entry is implemented by pointers to the registry entry types.
+type entry[T any] interface {
+	*T
+	base() *matcher
+	// substring tells whether aliases match anywhere in a name.
+	substring() bool
+}
+
+// load decodes a JSON array of entries and compiles their patterns.
+func load[T any, P entry[T]](b []byte) ([]P, error) {
+	var entries []P
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if err := e.base().compile(); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// loadOverride reads entries from path and merges them into defaults. An
+// entry replaces the default one with the same name; overrides take
+// precedence when matching, so they come first.
+func loadOverride[T any, P entry[T]](path string, defaults []P) ([]P, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+	overrides, err := load[T, P](b)
+	if err != nil {
+		return nil, fmt.Errorf("could not load %s: %w", path, err)
+	}
+
+	merged := append([]P(nil), overrides...)
+	for _, d := range defaults {
+		replaced := slices.ContainsFunc(overrides, func(o P) bool {
+			return o.base().Name == d.base().Name
+		})
+		if !replaced {
+			merged = append(merged, d)
+		}
+	}
+	return merged, nil
+}
+
+// lookup returns the first entry matching input.
+func lookup[T any, P entry[T]](entries []P, input string) (P, bool) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, false
+	}
+	for _, e := range entries {
+		if e.base().matches(input, e.substring()) {
+			return e, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,127 @@
+package registry
+
+import (
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+//go:embed stadiums.json
+var defaultStadiums []byte
+
+// Stadium is a venue the club plays at.
+type Stadium struct {
+	matcher
+	// Teams lists the canonical names of the clubs that play their home
+	// matches at the stadium.
+	Teams []string `json:"teams,omitempty"`
+	City  string   `json:"city,omitempty"`
+	// Country is the ISO 3166-1 alpha-2 code of the stadium's country.
+	Country string `json:"country,omitempty"`
+	Address string `json:"address,omitempty"`
+	// Timezone is an IANA time zone name, such as America/Sao_Paulo.
+	Timezone  string  `json:"timezone,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+}
+
+func (s *Stadium) base() *matcher {
+	return &s.matcher
+}
+
+// Stadium names must match as a whole: "Arena" is contained in "Arena MRV".
+func (s *Stadium) substring() bool {
+	return false
+}
+
+// HomeOf reports whether the stadium is the home ground of team.
+func (s *Stadium) HomeOf(team string) bool {
+	return slices.Contains(s.Teams, team)
+}
+
+// HasCoordinates reports whether the stadium location is known.
+func (s *Stadium) HasCoordinates() bool {
+	return s.Latitude != 0 || s.Longitude != 0
+}
+
+// Location returns the stadium time zone, or nil when it is unknown.
+func (s *Stadium) Location() *time.Location {
+	if s.Timezone == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil
+	}
+	return loc
+}
+
+// FullAddress returns the full address of the stadium, as in "Arena MRV, Av.
+// Presidente Juscelino Kubitschek, 3300, Belo Horizonte, BR".
+func (s *Stadium) FullAddress() string {
+	parts := []string{s.Name}
+	for _, part := range []string{s.Address, s.City, s.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Stadiums is an ordered list of stadiums; the first one matching a name wins.
+type Stadiums struct {
+	entries []*Stadium
+}
+
+// DefaultStadiums returns the embedded stadium registry.
+func DefaultStadiums() *Stadiums {
+	entries, err := load[Stadium](defaultStadiums)
+	if err == nil {
+		err = validateStadiums(entries)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("registry: invalid embedded stadiums: %v", err))
+	}
+	return &Stadiums{entries: entries}
+}
+
+// LoadStadiums returns the default registry extended with the entries read
+// from path.
+func LoadStadiums(path string) (*Stadiums, error) {
+	entries, err := loadOverride(path, DefaultStadiums().entries)
+	if err == nil {
+		err = validateStadiums(entries)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("LoadStadiums(): %w", err)
+	}
+	return &Stadiums{entries: entries}, nil
+}
+
+func validateStadiums(entries []*Stadium) error {
+	for _, s := range entries {
+		if s.Timezone == "" {
+			continue
+		}
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return fmt.Errorf("%s: timezone %q: %v: %w", s.Name, s.Timezone, err, ErrInvalidEntry)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the stadium known by name.
+func (s *Stadiums) Lookup(name string) (*Stadium, bool) {
+	return lookup(s.entries, name)
+}
+
+// Normalize returns the canonical name of a stadium, or name trimmed when it
+// is unknown.
+func (s *Stadiums) Normalize(name string) string {
+	if stadium, ok := s.Lookup(name); ok {
+		return stadium.Name
+	}
+	return strings.TrimSpace(name)
+}
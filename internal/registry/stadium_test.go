@@ -0,0 +1,80 @@
+package registry_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/romanodesouza/galendario/internal/registry"
+)
+
+func TestStadiumsNormalize(t *testing.T) {
+	stadiums := registry.DefaultStadiums()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "Arena MRV", want: "Arena MRV"},
+		{input: "Arena MRV - Belo Horizonte", want: "Arena MRV"},
+		{input: " arena mrv ", want: "Arena MRV"},
+		{input: "Estadio Gigante de Arroyito", want: "Gigante de Arroyito"},
+		{input: "Mineirao", want: "Mineirão"},
+		{input: "Arena", want: "Arena"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := stadiums.Normalize(tt.input); got != tt.want {
+				t.Errorf("Normalize(%q): expected %q, got %q", tt.input, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStadiumLookup(t *testing.T) {
+	stadium, ok := registry.DefaultStadiums().Lookup("Gigante de Arroyito")
+	if !ok {
+		t.Fatal("expected stadium to be found")
+	}
+	if !stadium.HasCoordinates() {
+		t.Error("expected stadium to have coordinates")
+	}
+	if got := stadium.Location(); got == nil || got.String() != "America/Argentina/Cordoba" {
+		t.Errorf("unexpected location: %v", got)
+	}
+	if got, want := stadium.FullAddress(), "Gigante de Arroyito, Rosario, AR"; got != want {
+		t.Errorf("FullAddress(): expected %q, got %q", want, got)
+	}
+}
+
+func TestLoadStadiums(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "stadiums.json")
+	override := `[{"name": "Ipatingão", "city": "Ipatinga", "country": "BR", "timezone": "America/Sao_Paulo"}]`
+	if err := os.WriteFile(path, []byte(override), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	stadiums, err := registry.LoadStadiums(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ipatingao, ok := stadiums.Lookup("Ipatingao")
+	if !ok || ipatingao.HasCoordinates() {
+		t.Errorf("expected override entry without coordinates to be found, got %+v", ipatingao)
+	}
+	if _, ok := stadiums.Lookup("Arena MRV"); !ok {
+		t.Error("expected defaults to be kept")
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`[{"name": "X", "timezone": "Mars/Olympus"}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry.LoadStadiums(invalid); !errors.Is(err, registry.ErrInvalidEntry) {
+		t.Errorf("err: expected %v, got %v", registry.ErrInvalidEntry, err)
+	}
+}
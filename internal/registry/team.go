@@ -0,0 +1,94 @@
+package registry
+
+import (
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+//go:embed teams.json
+var defaultTeams []byte
+
+// Team is a club the agenda may list.
+type Team struct {
+	matcher
+	// Country is the ISO 3166-1 alpha-2 code of the club's country.
+	Country string `json:"country,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
+func (t *Team) base() *matcher {
+	return &t.matcher
+}
+
+// Team names must match as a whole: "Atlético" is contained in "Atlético-GO".
+func (t *Team) substring() bool {
+	return false
+}
+
+// Teams is an ordered list of teams; the first one matching a name wins. It
+// records the names it could not find, so that a run can report them.
+type Teams struct {
+	entries []*Team
+
+	mu      sync.Mutex
+	unknown map[string]bool
+}
+
+// DefaultTeams returns the embedded team registry.
+func DefaultTeams() *Teams {
+	entries, err := load[Team](defaultTeams)
+	if err != nil {
+		panic(fmt.Sprintf("registry: invalid embedded teams: %v", err))
+	}
+	return &Teams{entries: entries}
+}
+
+// LoadTeams returns the default registry extended with the entries read from
+// path.
+func LoadTeams(path string) (*Teams, error) {
+	entries, err := loadOverride(path, DefaultTeams().entries)
+	if err != nil {
+		return nil, fmt.Errorf("LoadTeams(): %w", err)
+	}
+	return &Teams{entries: entries}, nil
+}
+
+// Lookup returns the team known by name.
+func (t *Teams) Lookup(name string) (*Team, bool) {
+	return lookup(t.entries, name)
+}
+
+// Normalize returns the canonical name of a team, or name trimmed when it is
+// unknown, in which case it is recorded.
+func (t *Teams) Normalize(name string) string {
+	if team, ok := t.Lookup(name); ok {
+		return team.Name
+	}
+
+	name = strings.TrimSpace(name)
+	if name != "" {
+		t.mu.Lock()
+		if t.unknown == nil {
+			t.unknown = make(map[string]bool)
+		}
+		t.unknown[name] = true
+		t.mu.Unlock()
+	}
+	return name
+}
+
+// Unknown returns the sorted names Normalize could not find.
+func (t *Teams) Unknown() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	names := make([]string, 0, len(t.unknown))
+	for name := range t.unknown {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,78 @@
+package registry_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/romanodesouza/galendario/internal/registry"
+)
+
+func TestTeamsNormalize(t *testing.T) {
+	teams := registry.DefaultTeams()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "Atlético", want: "Atlético"},
+		{input: " Atlético-MG ", want: "Atlético"},
+		{input: "Atletico MG", want: "Atlético"},
+		{input: "Clube Atlético Mineiro", want: "Atlético"},
+		{input: "GALO", want: "Atlético"},
+		{input: "Atlético-GO", want: "Atlético-GO"},
+		{input: "Athletico Paranaense", want: "Athletico-PR"},
+		{input: "Penarol", want: "Peñarol"},
+		{input: "Club Atlético Rosario Central", want: "Rosario Central"},
+		{input: " Tombense ", want: "Tombense"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := teams.Normalize(tt.input); got != tt.want {
+				t.Errorf("Normalize(%q): expected %q, got %q", tt.input, tt.want, got)
+			}
+		})
+	}
+
+	if diff := cmp.Diff([]string{"Tombense"}, teams.Unknown()); diff != "" {
+		t.Errorf("Unknown() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTeamsLookup(t *testing.T) {
+	team, ok := registry.DefaultTeams().Lookup("Atlético Mineiro")
+	if !ok {
+		t.Fatal("expected team to be found")
+	}
+	if team.Name != "Atlético" || team.Country != "BR" || team.Code != "CAM" {
+		t.Errorf("unexpected team: %+v", team)
+	}
+
+	if _, ok := registry.DefaultTeams().Lookup("Atlético Nacional"); ok {
+		t.Error("expected partial names not to match")
+	}
+}
+
+func TestLoadTeams(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "teams.json")
+	override := `[{"name": "Tombense", "aliases": ["Tombense FC"], "country": "BR", "code": "TOM"}]`
+	if err := os.WriteFile(path, []byte(override), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	teams, err := registry.LoadTeams(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := teams.Normalize("Tombense FC"); got != "Tombense" {
+		t.Errorf("expected override entry to be found, got %q", got)
+	}
+	if got := teams.Normalize("Galo"); got != "Atlético" {
+		t.Errorf("expected defaults to be kept, got %q", got)
+	}
+	if unknown := teams.Unknown(); len(unknown) != 0 {
+		t.Errorf("expected no unknown teams, got %v", unknown)
+	}
+}
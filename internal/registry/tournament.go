@@ -0,0 +1,70 @@
+package registry
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+)
+
+//go:embed tournaments.json
+var defaultTournaments []byte
+
+// Tournament is a competition the club takes part in.
+type Tournament struct {
+	matcher
+	// Organizer is the body running the competition, such as CBF, CONMEBOL
+	// or FMF.
+	Organizer string `json:"organizer,omitempty"`
+	Code      string `json:"code,omitempty"`
+	// Color is a CSS color name, as used by the iCalendar COLOR property.
+	Color string `json:"color,omitempty"`
+}
+
+func (t *Tournament) base() *matcher {
+	return &t.matcher
+}
+
+// Tournament names carry editions and sponsors, as in "Libertadores da
+// América 2024", so aliases match anywhere.
+func (t *Tournament) substring() bool {
+	return true
+}
+
+// Tournaments is an ordered list of tournaments; the first one matching a
+// name wins.
+type Tournaments struct {
+	entries []*Tournament
+}
+
+// DefaultTournaments returns the embedded tournament registry.
+func DefaultTournaments() *Tournaments {
+	entries, err := load[Tournament](defaultTournaments)
+	if err != nil {
+		panic(fmt.Sprintf("registry: invalid embedded tournaments: %v", err))
+	}
+	return &Tournaments{entries: entries}
+}
+
+// LoadTournaments returns the default registry extended with the entries
+// read from path.
+func LoadTournaments(path string) (*Tournaments, error) {
+	entries, err := loadOverride(path, DefaultTournaments().entries)
+	if err != nil {
+		return nil, fmt.Errorf("LoadTournaments(): %w", err)
+	}
+	return &Tournaments{entries: entries}, nil
+}
+
+// Lookup returns the tournament known by name.
+func (t *Tournaments) Lookup(name string) (*Tournament, bool) {
+	return lookup(t.entries, name)
+}
+
+// Normalize returns the canonical name of a tournament, or name trimmed when
+// it is unknown.
+func (t *Tournaments) Normalize(name string) string {
+	if tournament, ok := t.Lookup(name); ok {
+		return tournament.Name
+	}
+	return strings.TrimSpace(name)
+}
@@ -0,0 +1,79 @@
+package registry_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/romanodesouza/galendario/internal/registry"
+)
+
+func TestTournamentsNormalize(t *testing.T) {
+	tournaments := registry.DefaultTournaments()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "Libertadores da América 2024", want: "Libertadores"},
+		{input: "Copa Conmebol Libertadores", want: "Libertadores"},
+		{input: "  Brasileiro Série A 2024 ", want: "Brasileirão"},
+		{input: "Série A", want: "Brasileirão"},
+		{input: "Copa do Brasil", want: "Copa do Brasil"},
+		{input: "Supercopa do Brasil", want: "Supercopa do Brasil"},
+		{input: "mineiro 2025", want: "Campeonato Mineiro"},
+		{input: "Copa Sul-Americana", want: "Sul-Americana"},
+		{input: "Recopa Sul-Americana", want: "Recopa Sul-Americana"},
+		{input: "Copa do Mundo de Clubes da FIFA", want: "Mundial de Clubes"},
+		{input: "Mundial de Clubes 2025", want: "Mundial de Clubes"},
+		{input: "Amistoso internacional", want: "Amistoso"},
+		{input: " Florida Cup ", want: "Florida Cup"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := tournaments.Normalize(tt.input); got != tt.want {
+				t.Errorf("Normalize(%q): expected %q, got %q", tt.input, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLoadTournaments(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "tournaments.json")
+	override := `[
+		{"name": "Florida Cup", "aliases": ["florida"], "code": "FLO", "color": "orange"},
+		{"name": "Libertadores", "aliases": ["liberta"], "organizer": "CONMEBOL", "code": "CLA"}
+	]`
+	if err := os.WriteFile(path, []byte(override), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tournaments, err := registry.LoadTournaments(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	florida, ok := tournaments.Lookup("Florida Cup 2025")
+	if !ok || florida.Code != "FLO" {
+		t.Errorf("expected override entry to be found, got %+v", florida)
+	}
+	libertadores, ok := tournaments.Lookup("Liberta 2025")
+	if !ok || libertadores.Code != "CLA" {
+		t.Errorf("expected override entry to replace the default one, got %+v", libertadores)
+	}
+	if got := tournaments.Normalize("mineiro 2025"); got != "Campeonato Mineiro" {
+		t.Errorf("expected defaults to be kept, got %q", got)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`[{"name": "X", "patterns": ["("]}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry.LoadTournaments(invalid); !errors.Is(err, registry.ErrInvalidEntry) {
+		t.Errorf("err: expected %v, got %v", registry.ErrInvalidEntry, err)
+	}
+}
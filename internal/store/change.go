@@ -0,0 +1,67 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/romanodesouza/galendario/internal/event"
+)
+
+// ChangeKind tells how a match changed.
+type ChangeKind int
+
+const (
+	ChangeAdded ChangeKind = iota
+	ChangeUpdated
+	ChangeRemoved
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeUpdated:
+		return "updated"
+	case ChangeRemoved:
+		return "removed"
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// Change is a version of a match that differs from the previous one.
+type Change struct {
+	MatchID string
+	Kind    ChangeKind
+	// At is when the change was observed.
+	At time.Time
+	// Before is nil for added matches, After for removed ones.
+	Before *event.Event
+	After  *event.Event
+}
+
+// Changes returns the changes observed since the given time, inclusive,
+// ordered by time.
+func (s *Store) Changes(since time.Time) []Change {
+	var changes []Change
+	for _, m := range s.matches {
+		for i, v := range m.Versions {
+			if v.FirstSeen.Before(since) {
+				continue
+			}
+			c := Change{MatchID: m.ID, Kind: ChangeAdded, At: v.FirstSeen, After: &v.Event}
+			if i > 0 {
+				c.Kind, c.Before = ChangeUpdated, &m.Versions[i-1].Event
+			}
+			changes = append(changes, c)
+		}
+		if m.RemovedAt != nil && !m.RemovedAt.Before(since) {
+			changes = append(changes, Change{MatchID: m.ID, Kind: ChangeRemoved, At: *m.RemovedAt, Before: &m.Latest().Event})
+		}
+	}
+
+	slices.SortStableFunc(changes, func(a, b Change) int {
+		return a.At.Compare(b.At)
+	})
+	return changes
+}
@@ -0,0 +1,276 @@
+// Package store keeps the history of the matches seen on the agenda in a
+// single JSON file, so that runs can tell what changed and when.
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/romanodesouza/galendario/internal/atomicfile"
+	"github.com/romanodesouza/galendario/internal/event"
+)
+
+const (
+	// FormatVersion is the store file version this package reads.
+	FormatVersion = 1
+	// DefaultMaxShift is how far a match may be rescheduled and still be
+	// recognized as the same one.
+	DefaultMaxShift = 60 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidStore = errors.New("invalid store file")
+)
+
+// Match is the history of a single match.
+type Match struct {
+	// ID identifies the match across versions, even when rescheduled.
+	ID string `json:"id"`
+	// Versions lists every distinct version observed, oldest first.
+	Versions []*Version `json:"versions"`
+	// RemovedAt is when the match vanished from an agenda page covering its
+	// date, nil while it is listed.
+	RemovedAt *time.Time `json:"removed_at,omitempty"`
+}
+
+// Version is a state of a match as observed on the agenda.
+type Version struct {
+	Event event.Event `json:"event"`
+	// FirstSeen and LastSeen are the fetch times this version was observed
+	// first and last.
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// Latest returns the current version of the match.
+func (m *Match) Latest() *Version {
+	return m.Versions[len(m.Versions)-1]
+}
+
+// Created returns when the match was first seen.
+func (m *Match) Created() time.Time {
+	return m.Versions[0].FirstSeen
+}
+
+// LastModified returns when the match last changed, including its removal.
+func (m *Match) LastModified() time.Time {
+	if m.RemovedAt != nil && m.RemovedAt.After(m.Latest().FirstSeen) {
+		return *m.RemovedAt
+	}
+	return m.Latest().FirstSeen
+}
+
+// Sequence returns the number of times the match was rescheduled since first
+// seen, as the iCalendar SEQUENCE: only the changes clients must reschedule
+// for count.
+func (m *Match) Sequence() int {
+	n := 0
+	for i := 1; i < len(m.Versions); i++ {
+		if rescheduled(m.Versions[i-1].Event, m.Versions[i].Event) {
+			n++
+		}
+	}
+	return n
+}
+
+// rescheduled reports whether the match time, venue or status changed.
+func rescheduled(before, after event.Event) bool {
+	return !before.DateTime.Equal(after.DateTime) ||
+		before.TimeStatus != after.TimeStatus ||
+		before.Stadium != after.Stadium ||
+		before.Status != after.Status
+}
+
+// Store is the match history kept in a file. It is not safe for concurrent
+// use, neither within a process nor across processes.
+type Store struct {
+	// MaxShift bounds how far from its last known date a match is looked for.
+	MaxShift time.Duration
+
+	path    string
+	matches []*Match
+}
+
+type file struct {
+	Version int      `json:"version"`
+	Matches []*Match `json:"matches"`
+}
+
+// Open reads the store kept at path. A missing file yields an empty store,
+// created on Save.
+func Open(path string) (*Store, error) {
+	s := &Store{MaxShift: DefaultMaxShift, path: path}
+
+	b, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return s, nil
+	case err != nil:
+		return nil, fmt.Errorf("Open(): could not read store file: %w", err)
+	}
+
+	var f file
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("Open(): could not decode store file: %v: %w", err, ErrInvalidStore)
+	}
+	if f.Version != FormatVersion {
+		return nil, fmt.Errorf("Open(): unsupported version %d, want %d: %w", f.Version, FormatVersion, ErrInvalidStore)
+	}
+	for _, m := range f.Matches {
+		if len(m.Versions) == 0 {
+			return nil, fmt.Errorf("Open(): match %s has no versions: %w", m.ID, ErrInvalidStore)
+		}
+	}
+	s.matches = f.Matches
+	return s, nil
+}
+
+// Save writes the store to its file, replacing it atomically.
+func (s *Store) Save() error {
+	b, err := json.MarshalIndent(file{Version: FormatVersion, Matches: s.matches}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Store.Save(): could not encode store: %w", err)
+	}
+	if err := atomicfile.Write(s.path, b); err != nil {
+		return fmt.Errorf("Store.Save(): %w", err)
+	}
+	return nil
+}
+
+// Matches returns every match in the store, in the order first seen.
+func (s *Store) Matches() []*Match {
+	return slices.Clone(s.matches)
+}
+
+// Lookup returns the listed match whose current version is ev, as recorded by
+// Record.
+func (s *Store) Lookup(ev event.Event) (*Match, bool) {
+	for _, m := range s.matches {
+		latest := m.Latest().Event
+		if m.RemovedAt == nil && latest.MatchKey() == ev.MatchKey() && latest.DateTime.Equal(ev.DateTime) {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+// Record stores the events fetched at fetchedAt for the startDate - endDate
+// window, and returns the changes it observed. Listed matches of the window
+// the events do not include anymore are marked as removed.
+func (s *Store) Record(events []event.Event, startDate, endDate, fetchedAt time.Time) []Change {
+	seen := s.record(events, startDate, fetchedAt)
+	for _, m := range s.matches {
+		date := m.Latest().Event.DateTime
+		if seen[m] || m.RemovedAt != nil || date.Before(startDate) || date.After(endDate) {
+			continue
+		}
+		m.RemovedAt = &fetchedAt
+	}
+
+	return s.Changes(fetchedAt)
+}
+
+// RecordPartial is Record for a fetch known to have skipped matches, such as
+// the ones that could not be parsed: no match is marked as removed, as it may
+// only have been skipped.
+func (s *Store) RecordPartial(events []event.Event, startDate, fetchedAt time.Time) []Change {
+	s.record(events, startDate, fetchedAt)
+	return s.Changes(fetchedAt)
+}
+
+// record stores the events as versions of the matches they follow, and
+// returns those matches.
+func (s *Store) record(events []event.Event, startDate, fetchedAt time.Time) map[*Match]bool {
+	seen := make(map[*Match]bool)
+	for _, ev := range events {
+		m := s.follow(ev, startDate, seen)
+		seen[m] = true
+
+		if n := len(m.Versions); n > 0 && m.RemovedAt == nil && sameVersion(m.Versions[n-1].Event, ev) {
+			m.Versions[n-1].LastSeen = fetchedAt
+			continue
+		}
+		m.Versions = append(m.Versions, &Version{Event: ev, FirstSeen: fetchedAt, LastSeen: fetchedAt})
+		m.RemovedAt = nil
+	}
+	return seen
+}
+
+// follow returns the match ev is a version of, among the ones not seen yet,
+// or a new one.
+func (s *Store) follow(ev event.Event, startDate time.Time, seen map[*Match]bool) *Match {
+	unseen := slices.DeleteFunc(slices.Clone(s.matches), func(m *Match) bool {
+		return seen[m]
+	})
+	if m, ok := Follow(ev, unseen, (*Match).identity, startDate, s.MaxShift); ok {
+		return m
+	}
+
+	m := &Match{ID: matchID(ev)}
+	s.matches = append(s.matches, m)
+	return m
+}
+
+func (m *Match) identity() Identity {
+	latest := m.Latest().Event
+	return Identity{Key: latest.MatchKey(), DateTime: latest.DateTime, Removed: m.RemovedAt != nil}
+}
+
+// Identity is what Follow knows of a candidate match.
+type Identity struct {
+	Key string
+	// DateTime is the last known date of the match.
+	DateTime time.Time
+	// Removed tells the match vanished from the agenda before its date.
+	Removed bool
+}
+
+// Follow returns the candidate ev is a version of: among the ones identify
+// gives the same match key, the closest in date, no further than maxShift.
+// Candidates last dated before the day of startDate were played, unless they
+// were removed, and are left out so that the next leg of a pairing is not
+// taken for them. It is how a rescheduled match is told apart from a new one.
+func Follow[T any](
+	ev event.Event, candidates []T, identify func(T) Identity, startDate time.Time, maxShift time.Duration,
+) (T, bool) {
+	key := ev.MatchKey()
+	firstDay := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
+
+	var best T
+	var bestShift time.Duration
+	found := false
+	for _, c := range candidates {
+		id := identify(c)
+		if id.Key != key || (!id.Removed && id.DateTime.Before(firstDay)) {
+			continue
+		}
+		shift := ev.DateTime.Sub(id.DateTime).Abs()
+		if shift > maxShift {
+			continue
+		}
+		if !found || shift < bestShift {
+			best, bestShift, found = c, shift, true
+		}
+	}
+	return best, found
+}
+
+// sameVersion compares events by their encoding, so that times compare by
+// instant and offset whether freshly parsed or read back from the file.
+func sameVersion(a, b event.Event) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
+
+func matchID(ev event.Event) string {
+	sum := sha256.Sum256([]byte(ev.MatchKey() + ":" + ev.DateTime.UTC().Format(time.RFC3339)))
+	return fmt.Sprintf("%x", sum[:8])
+}
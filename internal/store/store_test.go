@@ -0,0 +1,254 @@
+package store_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/romanodesouza/galendario/internal/event"
+	"github.com/romanodesouza/galendario/internal/store"
+)
+
+func TestStoreRecord(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "store.json")
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, loc)
+	end := time.Date(2024, 7, 31, 23, 59, 59, 0, loc)
+
+	gremio := event.Event{
+		Tournament: "Brasileirão",
+		Stadium:    "Arena MRV",
+		DateTime:   time.Date(2024, 5, 11, 21, 0, 0, 0, loc),
+		HomeTeam:   "Atlético",
+		AwayTeam:   "Grêmio",
+	}
+	bahia := event.Event{
+		Tournament: "Brasileirão",
+		Stadium:    "Arena Fonte Nova",
+		DateTime:   time.Date(2024, 5, 19, 16, 0, 0, 0, loc),
+		HomeTeam:   "Bahia",
+		AwayTeam:   "Atlético",
+	}
+
+	// record runs a fetch against the store saved at path, and saves it back.
+	record := func(t *testing.T, events []event.Event, fetchedAt time.Time) ([]store.Change, *store.Store) {
+		t.Helper()
+		s, err := store.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		changes := s.Record(events, start, end, fetchedAt)
+		if err := s.Save(); err != nil {
+			t.Fatal(err)
+		}
+		return changes, s
+	}
+
+	kinds := func(changes []store.Change) []string {
+		var got []string
+		for _, c := range changes {
+			got = append(got, c.Kind.String())
+		}
+		return got
+	}
+
+	day1 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	changes, _ := record(t, []event.Event{gremio, bahia}, day1)
+	if diff := cmp.Diff([]string{"added", "added"}, kinds(changes)); diff != "" {
+		t.Errorf("first run changes mismatch (-want +got):\n%s", diff)
+	}
+
+	day2 := day1.AddDate(0, 0, 1)
+	changes, _ = record(t, []event.Event{gremio, bahia}, day2)
+	if len(changes) != 0 {
+		t.Errorf("expected no changes for an unchanged agenda, got %v", kinds(changes))
+	}
+
+	day3 := day2.AddDate(0, 0, 1)
+	moved := gremio
+	moved.DateTime = gremio.DateTime.AddDate(0, 0, 10)
+	changes, s := record(t, []event.Event{moved}, day3)
+	if diff := cmp.Diff([]string{"updated", "removed"}, kinds(changes)); diff != "" {
+		t.Errorf("third run changes mismatch (-want +got):\n%s", diff)
+	}
+	if !changes[0].Before.DateTime.Equal(gremio.DateTime) || !changes[0].After.DateTime.Equal(moved.DateTime) {
+		t.Errorf("unexpected update: %v -> %v", changes[0].Before.DateTime, changes[0].After.DateTime)
+	}
+
+	match, ok := s.Lookup(moved)
+	if !ok {
+		t.Fatal("expected rescheduled match to be found")
+	}
+	if match.Sequence() != 1 || !match.Created().Equal(day1) || !match.LastModified().Equal(day3) {
+		t.Errorf("unexpected metadata: sequence %d, created %v, last modified %v",
+			match.Sequence(), match.Created(), match.LastModified())
+	}
+	if got := match.Versions[0].LastSeen; !got.Equal(day2) {
+		t.Errorf("unexpected last seen time of the first version: %v", got)
+	}
+	if _, ok := s.Lookup(bahia); ok {
+		t.Error("expected removed match not to be found")
+	}
+
+	if got := kinds(s.Changes(day1)); len(got) != 4 {
+		t.Errorf("unexpected history: %v", got)
+	}
+
+	day4 := day3.AddDate(0, 0, 1)
+	broadcast := moved
+	broadcast.Broadcasts = []string{"Premiere"}
+	_, s = record(t, []event.Event{broadcast}, day4)
+	if match, ok := s.Lookup(broadcast); !ok || match.Sequence() != 1 || len(match.Versions) != 3 {
+		t.Errorf("expected a new version not counted in the sequence, got %v", match)
+	}
+}
+
+func TestOpenInvalidStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	if err := os.WriteFile(path, []byte(`{"version": 2}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Open(path); !errors.Is(err, store.ErrInvalidStore) {
+		t.Errorf("err: expected %v, got %v", store.ErrInvalidStore, err)
+	}
+}
+
+func TestStoreRecordAcrossRuns(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	classico := event.Event{
+		Tournament: "Campeonato Mineiro",
+		Stadium:    "Mineirão",
+		DateTime:   time.Date(2024, 2, 2, 16, 0, 0, 0, loc),
+		HomeTeam:   "Atlético",
+		AwayTeam:   "Cruzeiro",
+	}
+	final := classico
+	final.DateTime = time.Date(2024, 3, 9, 16, 0, 0, 0, loc)
+
+	// record runs a fetch of the three months from start, fetched on start.
+	record := func(t *testing.T, path string, events []event.Event, start time.Time) *store.Store {
+		t.Helper()
+		s, err := store.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Record(events, start, start.AddDate(0, 3, 0), start)
+		if err := s.Save(); err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	t.Run("it should not take a played match for the next leg of its pairing", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "store.json")
+		record(t, path, []event.Event{classico}, time.Date(2024, 2, 1, 12, 0, 0, 0, loc))
+		s := record(t, path, []event.Event{final}, time.Date(2024, 3, 1, 12, 0, 0, 0, loc))
+
+		matches := s.Matches()
+		if len(matches) != 2 || len(matches[0].Versions) != 1 || matches[0].ID == matches[1].ID {
+			t.Fatalf("expected two matches of one version each, got %d", len(matches))
+		}
+		if matches[1].Sequence() != 0 {
+			t.Errorf("unexpected sequence of the next leg: %d", matches[1].Sequence())
+		}
+	})
+
+	t.Run("it should follow a match removed before its date into the next window", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "store.json")
+		record(t, path, []event.Event{classico}, time.Date(2024, 2, 1, 12, 0, 0, 0, loc))
+		record(t, path, nil, time.Date(2024, 2, 1, 18, 0, 0, 0, loc))
+		s := record(t, path, []event.Event{final}, time.Date(2024, 3, 1, 12, 0, 0, 0, loc))
+
+		matches := s.Matches()
+		if len(matches) != 1 || len(matches[0].Versions) != 2 || matches[0].RemovedAt != nil {
+			t.Fatalf("expected the postponed match to be followed, got %d matches", len(matches))
+		}
+	})
+	t.Run("it should not remove matches missing from a partial fetch", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "store.json")
+		start := time.Date(2024, 2, 1, 12, 0, 0, 0, loc)
+		s := record(t, path, []event.Event{classico}, start)
+
+		if changes := s.RecordPartial(nil, start, start.Add(time.Hour)); len(changes) != 0 {
+			t.Errorf("unexpected changes: %v", changes)
+		}
+		if m, ok := s.Lookup(classico); !ok || m.RemovedAt != nil {
+			t.Error("expected the skipped match to stay listed")
+		}
+	})
+}
+
+func TestFollow(t *testing.T) {
+	ev := event.Event{
+		Tournament: "Brasileirão",
+		DateTime:   time.Date(2024, 5, 11, 21, 0, 0, 0, time.UTC),
+		HomeTeam:   "Atlético",
+		AwayTeam:   "Grêmio",
+	}
+
+	identify := func(id store.Identity) store.Identity {
+		return id
+	}
+	startDate := ev.DateTime.AddDate(0, 0, -5)
+	played := ev.DateTime.AddDate(0, 0, -7)
+
+	tests := []struct {
+		name       string
+		candidates []store.Identity
+		want       store.Identity
+		wantOK     bool
+	}{
+		{
+			name: "it should follow the candidate closest in date",
+			candidates: []store.Identity{
+				{Key: ev.MatchKey(), DateTime: ev.DateTime.AddDate(0, 0, -4)},
+				{Key: ev.MatchKey(), DateTime: ev.DateTime.AddDate(0, 0, 3)},
+			},
+			want:   store.Identity{Key: ev.MatchKey(), DateTime: ev.DateTime.AddDate(0, 0, 3)},
+			wantOK: true,
+		},
+		{
+			name:       "it should not follow a candidate of another pairing",
+			candidates: []store.Identity{{Key: "Brasileirão:Grêmio:Atlético", DateTime: ev.DateTime}},
+		},
+		{
+			name: "it should not follow a candidate beyond the max shift",
+			candidates: []store.Identity{
+				{Key: ev.MatchKey(), DateTime: ev.DateTime.Add(store.DefaultMaxShift + time.Hour)},
+			},
+		},
+		{
+			name:       "it should not follow a candidate played before the window",
+			candidates: []store.Identity{{Key: ev.MatchKey(), DateTime: played}},
+		},
+		{
+			name:       "it should follow a candidate removed before the window",
+			candidates: []store.Identity{{Key: ev.MatchKey(), DateTime: played, Removed: true}},
+			want:       store.Identity{Key: ev.MatchKey(), DateTime: played, Removed: true},
+			wantOK:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := store.Follow(ev, tt.candidates, identify, startDate, store.DefaultMaxShift)
+			if ok != tt.wantOK {
+				t.Fatalf("ok: expected %v, got %v", tt.wantOK, ok)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Follow() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}